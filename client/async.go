@@ -0,0 +1,67 @@
+//go:build js && wasm
+
+package client
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// RegisterAsync adds fn to o's FuncMap under the given
+// name like Register does, but the function exported
+// to JavaScript returns a Promise right away and calls
+// fn in its own goroutine, so fn can block, e.g. to
+// make requests with client/http, without deadlocking
+// the page's event loop. The Promise resolves with
+// fn's converted result, or rejects with a JavaScript
+// Error if fn returns an error or panics, so that
+// HTML event handlers can await it:
+//
+//	<button onclick="await saveUser(42)">Save</button>
+func (o *Oasis) RegisterAsync(name string, fn any) error {
+	call, err := typedFunc(name, fn)
+	if err != nil {
+		return err
+	}
+
+	o.AddToFuncMap(name, func(args ...js.Value) interface{} {
+		return promise(func() (js.Value, error) {
+			return call(args)
+		})
+	})
+
+	return nil
+}
+
+// promise returns a JavaScript Promise that is
+// settled with the results of calling fn in its
+// own goroutine
+func promise(fn func() (js.Value, error)) js.Value {
+	var executor js.Func
+
+	executor = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		executor.Release()
+
+		resolve, reject := args[0], args[1]
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					reject.Invoke(js.Global().Get("Error").New(fmt.Sprintf("panic: %v", r)))
+				}
+			}()
+
+			res, err := fn()
+			if err != nil {
+				reject.Invoke(js.Global().Get("Error").New(err.Error()))
+				return
+			}
+
+			resolve.Invoke(res)
+		}()
+
+		return nil
+	})
+
+	return js.Global().Get("Promise").New(executor)
+}
@@ -0,0 +1,165 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+// ErrAlreadyDefined is returned by DefineCustomElement
+// when a custom element with the same tag has already
+// been defined on the page
+var ErrAlreadyDefined = errors.New("custom element already defined")
+
+// ShadowMode is the mode of the shadow
+// root attached to a custom element
+type ShadowMode string
+
+const (
+	// NoShadow renders a custom element's
+	// contents into the element itself
+	NoShadow ShadowMode = ""
+	// OpenShadow attaches a shadow root that
+	// page scripts can reach through shadowRoot
+	OpenShadow ShadowMode = "open"
+	// ClosedShadow attaches a shadow root that
+	// only the ComponentDef's callbacks can reach
+	ClosedShadow ShadowMode = "closed"
+)
+
+// ComponentDef defines the behavior of a custom
+// element. Every callback is optional, and each
+// is passed the instance of the custom element
+// it was called for
+type ComponentDef struct {
+	// ObservedAttributes are the attributes
+	// whose changes AttributeChanged is called for
+	ObservedAttributes []string
+	// Shadow is the mode of the shadow root
+	// attached to each instance, if any
+	Shadow ShadowMode
+	// Created is called when an instance is constructed,
+	// with the root its contents should be rendered into:
+	// its shadow root, or the instance itself when Shadow
+	// is NoShadow. Instances without a shadow root must not
+	// be given children or attributes until Connected
+	Created func(el Element, root Element)
+	// Connected is called each time
+	// an instance is added to the page
+	Connected func(el Element)
+	// Disconnected is called each time an
+	// instance is removed from the page
+	Disconnected func(el Element)
+	// AttributeChanged is called when one of the
+	// ObservedAttributes is added, changed or removed
+	// on an instance; oldVal and newVal are empty if
+	// the attribute was absent
+	AttributeChanged func(el Element, name string, oldVal string, newVal string)
+}
+
+var (
+	defineOnce sync.Once
+	defineShim js.Value
+)
+
+// DefineCustomElement registers def as the behavior of
+// the custom element with the given tag through the
+// page's CustomElementRegistry, so that every <tag>
+// element, whether already on the page, rendered by the
+// server or created later, is upgraded to it. The tag must
+// be lowercase and contain a hyphen. Since custom elements
+// can't be undefined, the callbacks are never released
+func DefineCustomElement(tag string, def ComponentDef) (err error) {
+	if !strings.Contains(tag, "-") || strings.ToLower(tag) != tag {
+		return fmt.Errorf("invalid custom element tag %q: must be lowercase and contain a hyphen", tag)
+	}
+
+	registry := js.Global().Get("customElements")
+
+	if !registry.Call("get", tag).IsUndefined() {
+		return fmt.Errorf("%w: %s", ErrAlreadyDefined, tag)
+	}
+
+	defineOnce.Do(func() {
+		defineShim = js.Global().Get("Function").New(`return function(tag, observed, shadow, created, connected, disconnected, changed) {
+	class OasisElement extends HTMLElement {
+		static get observedAttributes() { return observed; }
+		constructor() {
+			super();
+			const root = shadow ? this.attachShadow({ mode: shadow }) : this;
+			if (created) created(this, root);
+		}
+		connectedCallback() { if (connected) connected(this); }
+		disconnectedCallback() { if (disconnected) disconnected(this); }
+		attributeChangedCallback(name, oldVal, newVal) { if (changed) changed(this, name, oldVal, newVal); }
+	}
+	customElements.define(tag, OasisElement);
+}`).Invoke()
+	})
+
+	observed := make([]any, len(def.ObservedAttributes))
+	for i := range def.ObservedAttributes {
+		observed[i] = def.ObservedAttributes[i]
+	}
+
+	callbacks := []any{
+		js.Null(),
+		js.Null(),
+		js.Null(),
+		js.Null(),
+	}
+
+	if def.Created != nil {
+		callbacks[0] = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			def.Created(Wrap(args[0]), Wrap(args[1]))
+			return nil
+		})
+	}
+
+	if def.Connected != nil {
+		callbacks[1] = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			def.Connected(Wrap(args[0]))
+			return nil
+		})
+	}
+
+	if def.Disconnected != nil {
+		callbacks[2] = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			def.Disconnected(Wrap(args[0]))
+			return nil
+		})
+	}
+
+	if def.AttributeChanged != nil {
+		callbacks[3] = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			def.AttributeChanged(Wrap(args[0]), args[1].String(), attrValue(args[2]), attrValue(args[3]))
+			return nil
+		})
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			for _, cb := range callbacks {
+				if fn, ok := cb.(js.Func); ok {
+					fn.Release()
+				}
+			}
+			err = fmt.Errorf("could not define custom element %s: %v", tag, r)
+		}
+	}()
+
+	defineShim.Invoke(append([]any{tag, observed, string(def.Shadow)}, callbacks...)...)
+
+	return nil
+}
+
+func attrValue(val js.Value) string {
+	if val.IsNull() || val.IsUndefined() {
+		return ""
+	}
+	return val.String()
+}
@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+)
+
+// ToJS converts val to a JavaScript value. js.Values,
+// and anything with a JSValue method, such as an Element,
+// are passed through as they are; anything else is encoded
+// as JSON and parsed back by JavaScript
+func ToJS(val any) (js.Value, error) {
+	switch v := val.(type) {
+	case js.Value:
+		return v, nil
+	case interface{ JSValue() js.Value }:
+		return v.JSValue(), nil
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return js.Undefined(), err
+	}
+
+	return js.Global().Get("JSON").Call("parse", string(b)), nil
+}
+
+// FromJS decodes val into a T through JSON. null and
+// undefined are decoded as the zero value of T
+func FromJS[T any](val js.Value) (T, error) {
+	var v T
+	err := DecodeJS(val, &v)
+	return v, err
+}
+
+// DecodeJS is like FromJS, except for it decodes
+// val into the value pointed to by ptr, like
+// json.Unmarshal does
+func DecodeJS(val js.Value, ptr any) error {
+	if val.IsUndefined() || val.IsNull() {
+		return nil
+	}
+
+	b := js.Global().Get("JSON").Call("stringify", val)
+	if b.IsUndefined() {
+		return fmt.Errorf("cannot decode a JavaScript %s", val.Type())
+	}
+
+	return json.Unmarshal([]byte(b.String()), ptr)
+}
@@ -0,0 +1,88 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/console"
+	"syscall/js"
+)
+
+// EventTarget is anything event listeners
+// can be added to, such as an Element, the
+// Window or the Document
+type EventTarget interface {
+	AddEventListener(name string, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription
+}
+
+// NewCustomEvent creates a new JavaScript CustomEvent
+// with the provided options, which may be nil, whose
+// detail is detail encoded as JSON, so that it can be
+// decoded again by listeners added with OnCustomEvent
+func NewCustomEvent[T any](name string, detail T, opts *EventOptions) (*Event, error) {
+	d, err := ToJS(detail)
+	if err != nil {
+		return nil, err
+	}
+
+	o := opts.jsValue()
+	o.Set("detail", d)
+
+	return &Event{
+		event: js.Global().Get("CustomEvent").New(name, o),
+	}, nil
+}
+
+// Detail decodes the detail of e,
+// a CustomEvent, into a T
+func Detail[T any](e Event) (T, error) {
+	return FromJS[T](e.event.Get("detail"))
+}
+
+// OnCustomEvent adds a listener for the event name to
+// target, like its AddEventListener does, which passes
+// fn the event's detail decoded into a T along with the
+// event itself. Events whose detail can't be decoded
+// are logged as errors and not passed to fn
+func OnCustomEvent[T any](target EventTarget, name string, fn func(detail T, e Event), opts ...ListenerOptions) Subscription {
+	return target.AddEventListener(name, func(args ...js.Value) interface{} {
+		e := Event{event: args[0]}
+
+		detail, err := Detail[T](e)
+		if err != nil {
+			console.ErrMessage(fmt.Sprintf("could not decode the detail of %s event: %s", name, err.Error()), nil)
+			return nil
+		}
+
+		fn(detail, e)
+
+		return nil
+	}, opts...)
+}
+
+// Publish dispatches a bubbling CustomEvent with
+// the given name and detail on the document, so
+// that it reaches every listener added with
+// Subscribe, whichever component added it
+func Publish[T any](name string, detail T) error {
+	e, err := NewCustomEvent(name, detail, NewEventOptions(true, false, false))
+	if err != nil {
+		return err
+	}
+
+	Document.doc.Call("dispatchEvent", e.event)
+
+	return nil
+}
+
+// Subscribe calls fn with the detail of every event with
+// the given name that reaches the document, decoded into
+// a T. This includes events sent with Publish as well as
+// events triggered by htmx, e.g. through an HX-Trigger
+// response header set with the server package's
+// TriggerEvent, as they bubble up from their elements
+func Subscribe[T any](name string, fn func(detail T), opts ...ListenerOptions) Subscription {
+	return OnCustomEvent(Document, name, func(detail T, e Event) {
+		fn(detail)
+	}, opts...)
+}
@@ -2,7 +2,10 @@
 
 package dom
 
-import "syscall/js"
+import (
+	"strings"
+	"syscall/js"
+)
 
 // Doc is a wrapper type to provide
 // methods to manipulate the global
@@ -16,36 +19,150 @@ var Document = &Doc{
 	doc: js.Global().Get("document"),
 }
 
-// GetElementsByTagName returns all Elements
-// in a document with the given name
-func (d *Doc) GetElementsByTagName(name string) Element {
+// JSValue returns the underlying
+// JavaScript document
+func (d *Doc) JSValue() js.Value {
+	return d.doc
+}
+
+// Body returns the document's <body> Element,
+// or nil if it doesn't have one
+func (d *Doc) Body() Element {
+	return nodeOrNil(d.doc.Get("body"))
+}
+
+// Head returns the document's <head> Element,
+// or nil if it doesn't have one
+func (d *Doc) Head() Element {
+	return nodeOrNil(d.doc.Get("head"))
+}
+
+// ActiveElement returns the Element that
+// currently has focus, or nil
+func (d *Doc) ActiveElement() Element {
+	return nodeOrNil(d.doc.Get("activeElement"))
+}
+
+// Title returns the document's title
+func (d *Doc) Title() string {
+	return d.doc.Get("title").String()
+}
+
+// SetTitle sets the document's title
+func (d *Doc) SetTitle(title string) {
+	d.doc.Set("title", title)
+}
+
+// CreateElement creates a new Element
+// with the given tag name that isn't
+// in the document yet
+func (d *Doc) CreateElement(tag string) Element {
 	return &element{
-		elem: d.doc.Call("getElementsByName", name),
+		elem: d.doc.Call("createElement", tag),
 	}
 }
 
-// GetElementById returns the child Element with
-// the matching id
-func (d *Doc) GetElementById(id string) Element {
+// CreateTextNode creates a new Text node
+// holding text, wrapped as an Element
+// so that it can be inserted like one
+func (d *Doc) CreateTextNode(text string) Element {
 	return &element{
-		elem: d.doc.Call("getElementById", id),
+		elem: d.doc.Call("createTextNode", text),
 	}
 }
 
+// CreateDocumentFragment creates a new, empty
+// DocumentFragment, wrapped as an Element, that
+// Elements can be appended to before inserting
+// them all into the document at once
+func (d *Doc) CreateDocumentFragment() Element {
+	return &element{
+		elem: d.doc.Call("createDocumentFragment"),
+	}
+}
+
+// QuerySelector returns the first Element
+// in the document matching any of the given
+// CSS selectors, or nil if none do
+func (d *Doc) QuerySelector(cssSelectors ...string) Element {
+	return nodeOrNil(d.doc.Call("querySelector", strings.Join(cssSelectors, ", ")))
+}
+
+// QuerySelectorAll returns every Element
+// in the document matching any of the given
+// CSS selectors, in document order
+func (d *Doc) QuerySelectorAll(cssSelectors ...string) []Element {
+	return elements(d.doc.Call("querySelectorAll", strings.Join(cssSelectors, ", ")))
+}
+
+// GetElementsByTagName returns all Elements
+// in a document with the given tag name
+func (d *Doc) GetElementsByTagName(name string) []Element {
+	return elements(d.doc.Call("getElementsByTagName", name))
+}
+
+// GetElementsByName returns all Elements in
+// a document with the given name attribute
+func (d *Doc) GetElementsByName(name string) []Element {
+	return elements(d.doc.Call("getElementsByName", name))
+}
+
+// GetElementById returns the child Element with
+// the matching id, or nil if there isn't one
+func (d *Doc) GetElementById(id string) Element {
+	return nodeOrNil(d.doc.Call("getElementById", id))
+}
+
 // GetElementsByClassName returns the child Elements with
 // the matching class
 func (d *Doc) GetElementsByClassName(class string) []Element {
-	e := d.doc.Call("getElementsByClassName", class)
+	return elements(d.doc.Call("getElementsByClassName", class))
+}
 
-	l := e.Length()
+// elements returns the Elements in a
+// JavaScript NodeList or HTMLCollection
+func elements(list js.Value) []Element {
+	l := list.Length()
 
 	elems := make([]Element, l)
 
 	for i := 0; i < l; i++ {
 		elems[i] = &element{
-			elem: e.Index(i),
+			elem: list.Index(i),
 		}
 	}
 
 	return elems
 }
+
+// AddEventListener allows you to add a custom event listener
+// to the document with the given name, configured by the first
+// of opts, if any are given. The returned Subscription
+// removes it
+func (d *Doc) AddEventListener(name string, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription {
+	return listen(d.doc, name, fn, opts)
+}
+
+// On adds an event listener to a specific "on" event,
+// like AddEventListener does
+func (d *Doc) On(name OnEvent, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription {
+	return listen(d.doc, name.eventType(), fn, opts)
+}
+
+// OnKeyboardEvent adds a listener for KeyboardEvents, such
+// as OnKeyDown and OnKeyUp, to the document like On does
+func (d *Doc) OnKeyboardEvent(name OnEvent, fn func(KeyboardEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(d.doc, name, keyboardEvent, fn, opts)
+}
+
+// OnMouseEvent adds a listener for MouseEvents, such
+// as OnClick, OnMouseMove and OnContextMenu, to the document like On does
+func (d *Doc) OnMouseEvent(name OnEvent, fn func(MouseEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(d.doc, name, mouseEvent, fn, opts)
+}
+
+// OnWheelEvent adds a listener for WheelEvents, such
+// as OnWheel, to the document like On does
+func (d *Doc) OnWheelEvent(name OnEvent, fn func(WheelEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(d.doc, name, wheelEvent, fn, opts)
+}
@@ -25,8 +25,10 @@ type Element interface {
 	// after this Element
 	AfterText(texts ...string)
 	// AddEventListener allows you to add a custom event listener
-	// to an Element with the given name
-	AddEventListener(name string, fn func(args ...js.Value) interface{})
+	// to an Element with the given name, configured by the first
+	// of opts, if any are given. The returned Subscription
+	// removes it
+	AddEventListener(name string, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription
 	// Append allows you to append a
 	// child Element to a parent Element's
 	// children Elements
@@ -194,6 +196,9 @@ type Element interface {
 	// a new text node at the given position
 	// relative to the Element it is called from
 	InsertAdjacentText(position InsertPosition, data string)
+	// JSValue returns the underlying JavaScript
+	// DOM element of the Element
+	JSValue() js.Value
 	// Matches takes in a slice of CSS
 	// selectors to match against and
 	// compares the Element this method
@@ -202,8 +207,36 @@ type Element interface {
 	// returns a bool describing whether
 	// they match
 	Matches(cssSelectors ...string) bool
-	// On adds an event listener to a specific "on" event
-	On(name OnEvent, fn func(args ...js.Value) interface{})
+	// On adds an event listener to a specific "on" event,
+	// like AddEventListener does
+	On(name OnEvent, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription
+	// OnClipboardEvent adds a listener for ClipboardEvents, such
+	// as OnCopy, OnCut and OnPaste, to the Element like On does
+	OnClipboardEvent(name OnEvent, fn func(ClipboardEvent), opts ...ListenerOptions) Subscription
+	// OnDragEvent adds a listener for DragEvents, such
+	// as OnDragStart, OnDragOver and OnDrop, to the Element like On does
+	OnDragEvent(name OnEvent, fn func(DragEvent), opts ...ListenerOptions) Subscription
+	// OnFormEvent adds a listener for FormEvents, such
+	// as OnChange, OnFocus, OnBlur and OnReset, to the Element like On does
+	OnFormEvent(name OnEvent, fn func(FormEvent), opts ...ListenerOptions) Subscription
+	// OnInputEvent adds a listener for InputEvents, such
+	// as OnInput, to the Element like On does
+	OnInputEvent(name OnEvent, fn func(InputEvent), opts ...ListenerOptions) Subscription
+	// OnKeyboardEvent adds a listener for KeyboardEvents, such
+	// as OnKeyDown and OnKeyUp, to the Element like On does
+	OnKeyboardEvent(name OnEvent, fn func(KeyboardEvent), opts ...ListenerOptions) Subscription
+	// OnMediaEvent adds a listener for MediaEvents, such
+	// as OnPlay, OnPause and OnTimeUpdate, to the Element like On does
+	OnMediaEvent(name OnEvent, fn func(MediaEvent), opts ...ListenerOptions) Subscription
+	// OnMouseEvent adds a listener for MouseEvents, such
+	// as OnClick, OnMouseMove and OnContextMenu, to the Element like On does
+	OnMouseEvent(name OnEvent, fn func(MouseEvent), opts ...ListenerOptions) Subscription
+	// OnSubmitEvent adds a listener for SubmitEvents, such
+	// as OnSubmit, to the Element like On does
+	OnSubmitEvent(name OnEvent, fn func(SubmitEvent), opts ...ListenerOptions) Subscription
+	// OnWheelEvent adds a listener for WheelEvents, such
+	// as OnWheel, to the Element like On does
+	OnWheelEvent(name OnEvent, fn func(WheelEvent), opts ...ListenerOptions) Subscription
 	// Prepend allows you to prepend a
 	// child Element to a parent Element's
 	// children Elements
@@ -229,9 +262,12 @@ type Element interface {
 	// attr from the Element it was called
 	// on
 	RemoveAttribute(attr string)
-	// RemoveEventListener removes the event listener from an Element
-	// with the given name. fn must match the same event listener that
-	// was set for the given name whenever AddEventListener was called
+	// RemoveEventListener was meant to remove the event listener
+	// fn from an Element, but since Go funcs can't be compared it
+	// can never find the listener AddEventListener added.
+	//
+	// Deprecated: call Remove on the Subscription returned by
+	// AddEventListener or On instead
 	RemoveEventListener(name string, fn func(args ...js.Value) interface{}, isCapture bool)
 	// RequestPointerLock lets you asynchronously
 	// ask for the pointer to be locked on the
@@ -295,11 +331,52 @@ type element struct {
 	elem js.Value
 }
 
+// Wrap returns the Element for the
+// given JavaScript DOM element
+func Wrap(elem js.Value) Element {
+	return &element{
+		elem: elem,
+	}
+}
+
+// NewElement defines the custom element name, unless
+// it has been defined already, so that initFunc is
+// invoked with each instance when it is created,
+// onMount when it is added to the page and onDismount
+// when it is removed from it, then creates and returns
+// a new instance of it. Any of the funcs may be their
+// zero value. Use DefineCustomElement for more control
+// over the custom element's behavior
 func NewElement(name string, initFunc js.Func, onMount js.Func, onDismount js.Func) Element {
-	js.Global().Call("makeComponent", name, initFunc, onMount, onDismount)
+	if js.Global().Get("customElements").Call("get", name).IsUndefined() {
+		def := ComponentDef{}
+
+		if initFunc.Truthy() {
+			def.Created = func(el Element, root Element) {
+				initFunc.Invoke(el.JSValue())
+			}
+		}
+
+		if onMount.Truthy() {
+			def.Connected = func(el Element) {
+				onMount.Invoke(el.JSValue())
+			}
+		}
+
+		if onDismount.Truthy() {
+			def.Disconnected = func(el Element) {
+				onDismount.Invoke(el.JSValue())
+			}
+		}
+
+		err := DefineCustomElement(name, def)
+		if err != nil {
+			console.ErrMessage(err.Error(), nil)
+		}
+	}
 
 	return &element{
-		elem: any(Document.GetElementsByTagName(name)).(*element).elem.Index(0),
+		elem: js.Global().Get("document").Call("createElement", name),
 	}
 }
 
@@ -340,11 +417,11 @@ func (e *element) AfterText(texts ...string) {
 }
 
 // AddEventListener allows you to add a custom event listener
-// to an Element with the given name
-func (e *element) AddEventListener(name string, fn func(args ...js.Value) interface{}) {
-	e.elem.Call("addEventListener", name, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		return fn(args...)
-	}))
+// to an Element with the given name, configured by the first
+// of opts, if any are given. The returned Subscription
+// removes it
+func (e *element) AddEventListener(name string, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription {
+	return listen(e.elem, name, fn, opts)
 }
 
 // Append allows you to append a
@@ -759,6 +836,12 @@ func (e *element) InsertAdjacentText(position InsertPosition, data string) {
 	e.elem.Call("insertAdjacentText", position, data)
 }
 
+// JSValue returns the underlying JavaScript
+// DOM element of the Element
+func (e *element) JSValue() js.Value {
+	return e.elem
+}
+
 // Matches takes in a slice of CSS
 // selectors to match against and
 // compares the Element this method
@@ -776,11 +859,64 @@ func (e *element) Matches(cssSelectors ...string) bool {
 	return e.elem.Call("matches", selectors...).Bool()
 }
 
-// On adds an event listener to a specific "on" event
-func (e *element) On(name OnEvent, fn func(args ...js.Value) interface{}) {
-	e.elem.Call("addEventListener", string(name), js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		return fn(args...)
-	}))
+// On adds an event listener to a specific "on" event,
+// like AddEventListener does
+func (e *element) On(name OnEvent, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription {
+	return listen(e.elem, name.eventType(), fn, opts)
+}
+
+// OnClipboardEvent adds a listener for ClipboardEvents, such
+// as OnCopy, OnCut and OnPaste, to the Element like On does
+func (e *element) OnClipboardEvent(name OnEvent, fn func(ClipboardEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, clipboardEvent, fn, opts)
+}
+
+// OnDragEvent adds a listener for DragEvents, such
+// as OnDragStart, OnDragOver and OnDrop, to the Element like On does
+func (e *element) OnDragEvent(name OnEvent, fn func(DragEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, dragEvent, fn, opts)
+}
+
+// OnFormEvent adds a listener for FormEvents, such
+// as OnChange, OnFocus, OnBlur and OnReset, to the Element like On does
+func (e *element) OnFormEvent(name OnEvent, fn func(FormEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, formEvent, fn, opts)
+}
+
+// OnInputEvent adds a listener for InputEvents, such
+// as OnInput, to the Element like On does
+func (e *element) OnInputEvent(name OnEvent, fn func(InputEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, inputEvent, fn, opts)
+}
+
+// OnKeyboardEvent adds a listener for KeyboardEvents, such
+// as OnKeyDown and OnKeyUp, to the Element like On does
+func (e *element) OnKeyboardEvent(name OnEvent, fn func(KeyboardEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, keyboardEvent, fn, opts)
+}
+
+// OnMediaEvent adds a listener for MediaEvents, such
+// as OnPlay, OnPause and OnTimeUpdate, to the Element like On does
+func (e *element) OnMediaEvent(name OnEvent, fn func(MediaEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, mediaEvent, fn, opts)
+}
+
+// OnMouseEvent adds a listener for MouseEvents, such
+// as OnClick, OnMouseMove and OnContextMenu, to the Element like On does
+func (e *element) OnMouseEvent(name OnEvent, fn func(MouseEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, mouseEvent, fn, opts)
+}
+
+// OnSubmitEvent adds a listener for SubmitEvents, such
+// as OnSubmit, to the Element like On does
+func (e *element) OnSubmitEvent(name OnEvent, fn func(SubmitEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, submitEvent, fn, opts)
+}
+
+// OnWheelEvent adds a listener for WheelEvents, such
+// as OnWheel, to the Element like On does
+func (e *element) OnWheelEvent(name OnEvent, fn func(WheelEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(e.elem, name, wheelEvent, fn, opts)
 }
 
 // Prepend allows you to prepend a
@@ -852,13 +988,14 @@ func (e *element) RemoveAttribute(attr string) {
 	e.elem.Call("removeAttribute", attr)
 }
 
-// RemoveEventListener removes the event listener from an Element
-// with the given name. fn must match the same event listener that
-// was set for the given name whenever AddEventListener was called
+// RemoveEventListener was meant to remove the event listener
+// fn from an Element, but since Go funcs can't be compared it
+// can never find the listener AddEventListener added.
+//
+// Deprecated: call Remove on the Subscription returned by
+// AddEventListener or On instead
 func (e *element) RemoveEventListener(name string, fn func(args ...js.Value) interface{}, isCapture bool) {
-	e.elem.Call("removeEventListener", name, js.FuncOf(func(this js.Value, args []js.Value) any {
-		return fn(args...)
-	}), isCapture)
+	console.ErrMessage(fmt.Sprintf("cannot remove %s event listener from element %s; call Remove on its Subscription instead", name, e.GetTagName()), nil)
 }
 
 type FullScreenOpts struct {
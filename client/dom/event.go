@@ -20,16 +20,101 @@ func NewEventOptions(bubbles bool, cancelable bool, composed bool) *EventOptions
 	}
 }
 
+// jsValue returns o as a JavaScript object,
+// or an empty one if o is nil
+func (o *EventOptions) jsValue() js.Value {
+	obj := js.Global().Get("Object").New()
+	if o == nil {
+		return obj
+	}
+	for k, v := range o.opts {
+		obj.Set(k, v)
+	}
+	return obj
+}
+
 // Event is a new JavaScript Event
 type Event struct {
 	event js.Value
 }
 
 // NewEvent creates a new *Event with the
-// provided options
+// provided options, which may be nil
 func NewEvent(name string, opts *EventOptions) *Event {
 	eventConstructor := js.Global().Get("Event")
 	return &Event{
-		event: eventConstructor.New(name, opts.opts),
+		event: eventConstructor.New(name, opts.jsValue()),
+	}
+}
+
+// WrapEvent returns the Event for the
+// given JavaScript Event
+func WrapEvent(event js.Value) Event {
+	return Event{
+		event: event,
+	}
+}
+
+// JSValue returns the underlying JavaScript Event
+func (e Event) JSValue() js.Value {
+	return e.event
+}
+
+// Type returns the name of the event, e.g. "click"
+func (e Event) Type() string {
+	return e.event.Get("type").String()
+}
+
+// Target returns the Element the event was
+// dispatched to, or nil if it has none
+func (e Event) Target() Element {
+	return nodeOrNil(e.event.Get("target"))
+}
+
+// CurrentTarget returns the Element whose
+// listener is currently handling the event,
+// or nil if it isn't an Element
+func (e Event) CurrentTarget() Element {
+	return nodeOrNil(e.event.Get("currentTarget"))
+}
+
+// PreventDefault keeps the browser from
+// taking the event's default action, such
+// as following a link or submitting a form
+func (e Event) PreventDefault() {
+	e.event.Call("preventDefault")
+}
+
+// DefaultPrevented reports whether
+// PreventDefault has been called
+func (e Event) DefaultPrevented() bool {
+	return e.event.Get("defaultPrevented").Bool()
+}
+
+// StopPropagation keeps the event from
+// reaching listeners on other Elements
+func (e Event) StopPropagation() {
+	e.event.Call("stopPropagation")
+}
+
+// StopImmediatePropagation keeps the event from
+// reaching any other listeners, including the
+// ones remaining on the current Element
+func (e Event) StopImmediatePropagation() {
+	e.event.Call("stopImmediatePropagation")
+}
+
+// TimeStamp returns the time, in milliseconds since
+// the page loaded, at which the event was created
+func (e Event) TimeStamp() float64 {
+	return e.event.Get("timeStamp").Float()
+}
+
+func nodeOrNil(val js.Value) Element {
+	if val.IsNull() || val.IsUndefined() {
+		return nil
+	}
+	return &element{
+		elem: val,
 	}
 }
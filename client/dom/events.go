@@ -0,0 +1,363 @@
+//go:build js && wasm
+
+package dom
+
+import "syscall/js"
+
+// MouseEvent is passed to listeners added
+// with OnMouseEvent, for events such as
+// OnClick, OnMouseDown and OnContextMenu
+type MouseEvent struct {
+	Event
+}
+
+// ClientX returns the horizontal position of the
+// pointer relative to the viewport
+func (m MouseEvent) ClientX() float64 {
+	return m.event.Get("clientX").Float()
+}
+
+// ClientY returns the vertical position of the
+// pointer relative to the viewport
+func (m MouseEvent) ClientY() float64 {
+	return m.event.Get("clientY").Float()
+}
+
+// PageX returns the horizontal position of the
+// pointer relative to the whole document
+func (m MouseEvent) PageX() float64 {
+	return m.event.Get("pageX").Float()
+}
+
+// PageY returns the vertical position of the
+// pointer relative to the whole document
+func (m MouseEvent) PageY() float64 {
+	return m.event.Get("pageY").Float()
+}
+
+// OffsetX returns the horizontal position of the
+// pointer relative to the target Element
+func (m MouseEvent) OffsetX() float64 {
+	return m.event.Get("offsetX").Float()
+}
+
+// OffsetY returns the vertical position of the
+// pointer relative to the target Element
+func (m MouseEvent) OffsetY() float64 {
+	return m.event.Get("offsetY").Float()
+}
+
+// Button returns which button was pressed or
+// released: 0 for the main button, 1 for the
+// middle button and 2 for the secondary button
+func (m MouseEvent) Button() int {
+	return m.event.Get("button").Int()
+}
+
+// Buttons returns a bitmask of the
+// buttons held down during the event
+func (m MouseEvent) Buttons() int {
+	return m.event.Get("buttons").Int()
+}
+
+// AltKey reports whether alt was held down
+func (m MouseEvent) AltKey() bool {
+	return m.event.Get("altKey").Bool()
+}
+
+// CtrlKey reports whether control was held down
+func (m MouseEvent) CtrlKey() bool {
+	return m.event.Get("ctrlKey").Bool()
+}
+
+// MetaKey reports whether meta was held down
+func (m MouseEvent) MetaKey() bool {
+	return m.event.Get("metaKey").Bool()
+}
+
+// ShiftKey reports whether shift was held down
+func (m MouseEvent) ShiftKey() bool {
+	return m.event.Get("shiftKey").Bool()
+}
+
+// RelatedTarget returns the Element the pointer
+// left or entered, for events like OnMouseOver
+// and OnMouseOut, or nil
+func (m MouseEvent) RelatedTarget() Element {
+	return nodeOrNil(m.event.Get("relatedTarget"))
+}
+
+// WheelEvent is passed to listeners added
+// with OnWheelEvent, for OnWheel events
+type WheelEvent struct {
+	MouseEvent
+}
+
+// DeltaX returns the horizontal scroll amount
+func (w WheelEvent) DeltaX() float64 {
+	return w.event.Get("deltaX").Float()
+}
+
+// DeltaY returns the vertical scroll amount
+func (w WheelEvent) DeltaY() float64 {
+	return w.event.Get("deltaY").Float()
+}
+
+// DeltaZ returns the scroll amount along the z-axis
+func (w WheelEvent) DeltaZ() float64 {
+	return w.event.Get("deltaZ").Float()
+}
+
+// DeltaMode returns the unit of the deltas: 0
+// for pixels, 1 for lines and 2 for pages
+func (w WheelEvent) DeltaMode() int {
+	return w.event.Get("deltaMode").Int()
+}
+
+// DragEvent is passed to listeners added
+// with OnDragEvent, for events such as
+// OnDragStart, OnDragOver and OnDrop
+type DragEvent struct {
+	MouseEvent
+}
+
+// DataTransfer returns the event's
+// JavaScript DataTransfer
+func (d DragEvent) DataTransfer() js.Value {
+	return d.event.Get("dataTransfer")
+}
+
+// Data returns the dragged data of
+// the given format, e.g. "text/plain"
+func (d DragEvent) Data(format string) string {
+	dt := d.DataTransfer()
+	if dt.IsNull() {
+		return ""
+	}
+	return dt.Call("getData", format).String()
+}
+
+// SetData sets the dragged data
+// of the given format
+func (d DragEvent) SetData(format string, data string) {
+	if dt := d.DataTransfer(); !dt.IsNull() {
+		dt.Call("setData", format, data)
+	}
+}
+
+// KeyboardEvent is passed to listeners added
+// with OnKeyboardEvent, for events such as
+// OnKeyDown and OnKeyUp
+type KeyboardEvent struct {
+	Event
+}
+
+// Key returns the value of the key pressed,
+// e.g. "a", "A" or "Enter"
+func (k KeyboardEvent) Key() string {
+	return k.event.Get("key").String()
+}
+
+// Code returns the physical key pressed,
+// regardless of layout, e.g. "KeyA"
+func (k KeyboardEvent) Code() string {
+	return k.event.Get("code").String()
+}
+
+// Repeat reports whether the key is being
+// held down so that the event repeats
+func (k KeyboardEvent) Repeat() bool {
+	return k.event.Get("repeat").Bool()
+}
+
+// IsComposing reports whether the event
+// happened during text composition
+func (k KeyboardEvent) IsComposing() bool {
+	return k.event.Get("isComposing").Bool()
+}
+
+// AltKey reports whether alt was held down
+func (k KeyboardEvent) AltKey() bool {
+	return k.event.Get("altKey").Bool()
+}
+
+// CtrlKey reports whether control was held down
+func (k KeyboardEvent) CtrlKey() bool {
+	return k.event.Get("ctrlKey").Bool()
+}
+
+// MetaKey reports whether meta was held down
+func (k KeyboardEvent) MetaKey() bool {
+	return k.event.Get("metaKey").Bool()
+}
+
+// ShiftKey reports whether shift was held down
+func (k KeyboardEvent) ShiftKey() bool {
+	return k.event.Get("shiftKey").Bool()
+}
+
+// FormEvent is passed to listeners added
+// with OnFormEvent, for events such as
+// OnChange, OnFocus, OnBlur and OnReset
+type FormEvent struct {
+	Event
+}
+
+// Value returns the value of the
+// event's target, such as an <input>
+func (f FormEvent) Value() string {
+	v := f.event.Get("target").Get("value")
+	if v.IsUndefined() || v.IsNull() {
+		return ""
+	}
+	return v.String()
+}
+
+// Checked reports whether the event's
+// target, such as a checkbox, is checked
+func (f FormEvent) Checked() bool {
+	return f.event.Get("target").Get("checked").Truthy()
+}
+
+// InputEvent is passed to listeners added
+// with OnInputEvent, for OnInput events
+type InputEvent struct {
+	FormEvent
+}
+
+// Data returns the inserted characters,
+// or an empty string if there are none
+func (i InputEvent) Data() string {
+	d := i.event.Get("data")
+	if d.IsUndefined() || d.IsNull() {
+		return ""
+	}
+	return d.String()
+}
+
+// InputType returns the kind of edit
+// made, e.g. "insertText"
+func (i InputEvent) InputType() string {
+	return i.event.Get("inputType").String()
+}
+
+// IsComposing reports whether the event
+// happened during text composition
+func (i InputEvent) IsComposing() bool {
+	return i.event.Get("isComposing").Bool()
+}
+
+// SubmitEvent is passed to listeners added
+// with OnSubmitEvent, for OnSubmit events
+type SubmitEvent struct {
+	Event
+}
+
+// Submitter returns the Element, such as a
+// button, that submitted the form, or nil
+func (s SubmitEvent) Submitter() Element {
+	return nodeOrNil(s.event.Get("submitter"))
+}
+
+// ClipboardEvent is passed to listeners added
+// with OnClipboardEvent, for OnCopy, OnCut
+// and OnPaste events
+type ClipboardEvent struct {
+	Event
+}
+
+// Data returns the clipboard's data
+// of the given format, e.g. "text/plain"
+func (c ClipboardEvent) Data(format string) string {
+	cd := c.event.Get("clipboardData")
+	if cd.IsNull() || cd.IsUndefined() {
+		return ""
+	}
+	return cd.Call("getData", format).String()
+}
+
+// SetData sets the clipboard's data of the given
+// format. Call PreventDefault for it to take effect
+func (c ClipboardEvent) SetData(format string, data string) {
+	cd := c.event.Get("clipboardData")
+	if cd.IsNull() || cd.IsUndefined() {
+		return
+	}
+	cd.Call("setData", format, data)
+}
+
+// MediaEvent is passed to listeners added
+// with OnMediaEvent, for events such as
+// OnPlay, OnPause and OnTimeUpdate
+type MediaEvent struct {
+	Event
+}
+
+// CurrentTime returns the playback position
+// of the target media Element, in seconds
+func (m MediaEvent) CurrentTime() float64 {
+	return m.event.Get("target").Get("currentTime").Float()
+}
+
+// Duration returns the length of the target
+// media Element, in seconds
+func (m MediaEvent) Duration() float64 {
+	return m.event.Get("target").Get("duration").Float()
+}
+
+// Paused reports whether the target
+// media Element is paused
+func (m MediaEvent) Paused() bool {
+	return m.event.Get("target").Get("paused").Bool()
+}
+
+// Volume returns the volume of the target
+// media Element, from 0 to 1
+func (m MediaEvent) Volume() float64 {
+	return m.event.Get("target").Get("volume").Float()
+}
+
+// listenTyped adds fn as a listener for name on target,
+// passing it each event wrapped by wrap
+func listenTyped[E any](target js.Value, name OnEvent, wrap func(Event) E, fn func(E), opts []ListenerOptions) Subscription {
+	return listen(target, name.eventType(), func(args ...js.Value) interface{} {
+		fn(wrap(Event{event: args[0]}))
+		return nil
+	}, opts)
+}
+
+func mouseEvent(e Event) MouseEvent {
+	return MouseEvent{e}
+}
+
+func wheelEvent(e Event) WheelEvent {
+	return WheelEvent{MouseEvent{e}}
+}
+
+func dragEvent(e Event) DragEvent {
+	return DragEvent{MouseEvent{e}}
+}
+
+func keyboardEvent(e Event) KeyboardEvent {
+	return KeyboardEvent{e}
+}
+
+func formEvent(e Event) FormEvent {
+	return FormEvent{e}
+}
+
+func inputEvent(e Event) InputEvent {
+	return InputEvent{FormEvent{e}}
+}
+
+func submitEvent(e Event) SubmitEvent {
+	return SubmitEvent{e}
+}
+
+func clipboardEvent(e Event) ClipboardEvent {
+	return ClipboardEvent{e}
+}
+
+func mediaEvent(e Event) MediaEvent {
+	return MediaEvent{e}
+}
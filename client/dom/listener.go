@@ -0,0 +1,95 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"context"
+	"sync"
+	"syscall/js"
+)
+
+// Subscription is returned whenever an event
+// listener is added so that it can be removed
+type Subscription interface {
+	// Remove detaches the event listener and
+	// releases its callback. It is safe to call
+	// more than once, and after the listener has
+	// already been removed by its ListenerOptions
+	Remove()
+}
+
+// ListenerOptions configures how an
+// event listener is added
+type ListenerOptions struct {
+	// Once removes the listener after
+	// it has been called once
+	Once bool
+	// Passive promises the browser that the
+	// listener won't call preventDefault, so
+	// that e.g. scrolling isn't blocked by it
+	Passive bool
+	// Capture calls the listener during the capture
+	// phase, before any listeners on the event's target
+	Capture bool
+	// Signal removes the listener once it is done
+	Signal context.Context
+}
+
+type listener struct {
+	target  js.Value
+	name    string
+	fn      js.Func
+	capture bool
+	once    sync.Once
+	done    chan struct{}
+}
+
+// listen adds fn as a listener for the event name on
+// target with the first of opts, if any are given
+func listen(target js.Value, name string, fn func(args ...js.Value) interface{}, opts []ListenerOptions) Subscription {
+	o := ListenerOptions{}
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	l := &listener{
+		target:  target,
+		name:    name,
+		capture: o.Capture,
+		done:    make(chan struct{}),
+	}
+
+	l.fn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if o.Once {
+			defer l.Remove()
+		}
+		return fn(args...)
+	})
+
+	jsOpts := js.Global().Get("Object").New()
+	jsOpts.Set("once", o.Once)
+	jsOpts.Set("passive", o.Passive)
+	jsOpts.Set("capture", o.Capture)
+
+	target.Call("addEventListener", name, l.fn, jsOpts)
+
+	if o.Signal != nil {
+		go func() {
+			select {
+			case <-o.Signal.Done():
+				l.Remove()
+			case <-l.done:
+			}
+		}()
+	}
+
+	return l
+}
+
+func (l *listener) Remove() {
+	l.once.Do(func() {
+		l.target.Call("removeEventListener", l.name, l.fn, l.capture)
+		l.fn.Release()
+		close(l.done)
+	})
+}
@@ -0,0 +1,168 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/console"
+	"net/url"
+	"syscall/js"
+)
+
+// Location is a wrapper type to provide
+// methods to read and change the URL of
+// the page
+type Location struct {
+	loc js.Value
+}
+
+// Href returns the full URL of the page
+func (l *Location) Href() string {
+	return l.loc.Get("href").String()
+}
+
+// URL returns the URL of the page, parsed
+func (l *Location) URL() (*url.URL, error) {
+	return url.Parse(l.Href())
+}
+
+// Origin returns the scheme, host
+// and port of the page's URL
+func (l *Location) Origin() string {
+	return l.loc.Get("origin").String()
+}
+
+// Host returns the host and port
+// of the page's URL
+func (l *Location) Host() string {
+	return l.loc.Get("host").String()
+}
+
+// Pathname returns the path of the
+// page's URL, starting with a "/"
+func (l *Location) Pathname() string {
+	return l.loc.Get("pathname").String()
+}
+
+// Search returns the query of the page's
+// URL, starting with a "?", or an empty string
+func (l *Location) Search() string {
+	return l.loc.Get("search").String()
+}
+
+// Hash returns the fragment of the page's
+// URL, starting with a "#", or an empty string
+func (l *Location) Hash() string {
+	return l.loc.Get("hash").String()
+}
+
+// Assign navigates to url, keeping
+// the current page in the history
+func (l *Location) Assign(url string) {
+	l.loc.Call("assign", url)
+}
+
+// Replace navigates to url, replacing the
+// current page in the history
+func (l *Location) Replace(url string) {
+	l.loc.Call("replace", url)
+}
+
+// Reload reloads the page
+func (l *Location) Reload() {
+	l.loc.Call("reload")
+}
+
+// History is a wrapper type to provide
+// methods to manipulate the session
+// history of the page
+type History struct {
+	history js.Value
+}
+
+// Length returns the number of entries in
+// the session history, the current one included
+func (h *History) Length() int {
+	return h.history.Get("length").Int()
+}
+
+// PushState adds an entry for url, which may be
+// empty to keep the current URL, to the session
+// history, with state encoded as JSON so that it
+// can be decoded again by listeners added with
+// OnPopStateEvent, or with HistoryState
+func (h *History) PushState(state any, url string) error {
+	s, err := ToJS(state)
+	if err != nil {
+		return err
+	}
+
+	h.history.Call("pushState", s, "", historyURL(url))
+
+	return nil
+}
+
+// ReplaceState works like PushState, except for it
+// replaces the current entry of the session history
+func (h *History) ReplaceState(state any, url string) error {
+	s, err := ToJS(state)
+	if err != nil {
+		return err
+	}
+
+	h.history.Call("replaceState", s, "", historyURL(url))
+
+	return nil
+}
+
+// Back goes back one entry
+// in the session history
+func (h *History) Back() {
+	h.history.Call("back")
+}
+
+// Forward goes forward one entry
+// in the session history
+func (h *History) Forward() {
+	h.history.Call("forward")
+}
+
+// Go moves delta entries through the session
+// history, backwards if delta is negative
+func (h *History) Go(delta int) {
+	h.history.Call("go", delta)
+}
+
+func historyURL(url string) any {
+	if url == "" {
+		return js.Undefined()
+	}
+	return url
+}
+
+// HistoryState decodes the state of the current
+// session history entry into a T
+func HistoryState[T any]() (T, error) {
+	return FromJS[T](Window.window.Get("history").Get("state"))
+}
+
+// OnPopStateEvent calls fn whenever the user navigates
+// between session history entries, such as with
+// the back button, with the state of the entry
+// navigated to decoded into a T, along with the
+// event itself. The returned Subscription stops it
+func OnPopStateEvent[T any](fn func(state T, e Event), opts ...ListenerOptions) Subscription {
+	return Window.On(OnPopState, func(args ...js.Value) interface{} {
+		e := Event{event: args[0]}
+
+		state, err := FromJS[T](e.event.Get("state"))
+		if err != nil {
+			console.ErrMessage(fmt.Sprintf("could not decode the state of popstate event: %s", err.Error()), nil)
+			return nil
+		}
+
+		fn(state, e)
+
+		return nil
+	}, opts...)
+}
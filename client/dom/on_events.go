@@ -2,8 +2,19 @@
 
 package dom
 
+import "strings"
+
+// OnEvent is the name of an HTML "on" event
+// attribute, such as "onclick"
 type OnEvent string
 
+// eventType returns the event type to listen for
+// with addEventListener, which is the OnEvent
+// without its "on" prefix
+func (o OnEvent) eventType() string {
+	return strings.TrimPrefix(string(o), "on")
+}
+
 const (
 	// Window Events
 	//
@@ -66,9 +77,11 @@ const (
 	//
 	// Clipboard Events
 	//
-	OnCopy         OnEvent = "oncopy"
-	OnCut          OnEvent = "oncut"
-	OnPasteOnEvent         = "onpaste"
+	OnCopy  OnEvent = "oncopy"
+	OnCut   OnEvent = "oncut"
+	OnPaste OnEvent = "onpaste"
+	// Deprecated: use OnPaste, which is an OnEvent
+	OnPasteOnEvent = "onpaste"
 	//
 	// Media Events
 	//
@@ -0,0 +1,62 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"context"
+	"errors"
+	"syscall/js"
+)
+
+// Await blocks until promise settles or ctx is done,
+// and returns the value promise resolved with, or an
+// error if it was rejected. Rejections with an
+// AbortError, e.g. from aborting a fetch, are
+// returned as context.Canceled.
+//
+// Await, like every call in Oasis that blocks on
+// JavaScript, must be called from its own goroutine,
+// never directly from a function called by JavaScript.
+// JavaScript can only settle promise once that function
+// has returned, so blocking in it deadlocks the page's
+// event loop
+func Await(ctx context.Context, promise js.Value) (js.Value, error) {
+	type result struct {
+		val js.Value
+		err error
+	}
+
+	ch := make(chan result, 1)
+
+	var then, catch js.Func
+
+	then = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		then.Release()
+		catch.Release()
+		ch <- result{val: args[0]}
+		return nil
+	})
+
+	catch = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		then.Release()
+		catch.Release()
+		ch <- result{err: rejection(args[0])}
+		return nil
+	})
+
+	promise.Call("then", then, catch)
+
+	select {
+	case res := <-ch:
+		return res.val, res.err
+	case <-ctx.Done():
+		return js.Value{}, ctx.Err()
+	}
+}
+
+func rejection(reason js.Value) error {
+	if reason.Type() == js.TypeObject && reason.Get("name").String() == "AbortError" {
+		return context.Canceled
+	}
+	return errors.New(js.Global().Get("String").Invoke(reason).String())
+}
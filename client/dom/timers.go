@@ -0,0 +1,145 @@
+//go:build js && wasm
+
+package dom
+
+import (
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Timer is returned by the Win methods that
+// schedule calls so that they can be canceled
+type Timer interface {
+	// Stop cancels any calls that haven't
+	// happened yet. It is safe to call more
+	// than once
+	Stop()
+}
+
+type timeout struct {
+	timer *time.Timer
+}
+
+func (t *timeout) Stop() {
+	t.timer.Stop()
+}
+
+// SetTimeout calls fn in its own goroutine once d
+// has passed, like time.AfterFunc, unless the
+// returned Timer is stopped first
+func (w *Win) SetTimeout(d time.Duration, fn func()) Timer {
+	return &timeout{
+		timer: time.AfterFunc(d, fn),
+	}
+}
+
+type interval struct {
+	once sync.Once
+	done chan struct{}
+}
+
+func (i *interval) Stop() {
+	i.once.Do(func() {
+		close(i.done)
+	})
+}
+
+// SetInterval calls fn every d, in its own goroutine,
+// until the returned Timer is stopped. Calls are
+// dropped while fn is still running
+func (w *Win) SetInterval(d time.Duration, fn func()) Timer {
+	i := &interval{
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				// a tick and Stop can both be ready
+				select {
+				case <-i.done:
+					return
+				default:
+				}
+				fn()
+			case <-i.done:
+				return
+			}
+		}
+	}()
+
+	return i
+}
+
+type frame struct {
+	window  js.Value
+	mu      sync.Mutex
+	id      js.Value
+	fn      js.Func
+	stopped bool
+}
+
+func (f *frame) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.stopped {
+		return
+	}
+
+	f.window.Call("cancelAnimationFrame", f.id)
+	f.release()
+}
+
+// release must be called with f.mu held
+func (f *frame) release() {
+	f.stopped = true
+	f.fn.Release()
+}
+
+// RequestAnimationFrame calls fn before the browser
+// next repaints, with the time in milliseconds since
+// the page loaded, unless the returned Timer is stopped
+// first. fn is called from the page's event loop, so
+// it must not block
+func (w *Win) RequestAnimationFrame(fn func(timestamp float64)) Timer {
+	return w.AnimationLoop(func(timestamp float64) bool {
+		fn(timestamp)
+		return false
+	})
+}
+
+// AnimationLoop calls fn before every repaint, like
+// RequestAnimationFrame, until fn returns false or
+// the returned Timer is stopped
+func (w *Win) AnimationLoop(fn func(timestamp float64) bool) Timer {
+	f := &frame{
+		window: w.window,
+	}
+
+	f.fn = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		next := fn(args[0].Float())
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch {
+		case f.stopped:
+		case !next:
+			f.release()
+		default:
+			f.id = f.window.Call("requestAnimationFrame", f.fn)
+		}
+
+		return nil
+	})
+
+	f.id = f.window.Call("requestAnimationFrame", f.fn)
+
+	return f
+}
@@ -16,35 +16,101 @@ var Window = &Win{
 	window: js.Global().Get("window"),
 }
 
-// GetElementById returns the child Element with
-// the matching id
-func (w *Win) GetElementById(id string) Element {
-	return &element{
-		elem: w.window.Call("getElementById", id),
-	}
+// JSValue returns the underlying
+// JavaScript window
+func (w *Win) JSValue() js.Value {
+	return w.window
+}
+
+// InnerWidth returns the width of the
+// window's viewport, in pixels
+func (w *Win) InnerWidth() int {
+	return w.window.Get("innerWidth").Int()
 }
 
-// GetElementsByClassName returns the child Elements with
-// the matching class
-func (w *Win) GetElementsByClassName(class string) []Element {
-	e := w.window.Call("getElementsByClassName", class)
+// InnerHeight returns the height of the
+// window's viewport, in pixels
+func (w *Win) InnerHeight() int {
+	return w.window.Get("innerHeight").Int()
+}
 
-	l := e.Length()
+// Location returns the window's Location
+func (w *Win) Location() *Location {
+	return &Location{
+		loc: w.window.Get("location"),
+	}
+}
 
-	elems := make([]Element, l)
+// History returns the window's History
+func (w *Win) History() *History {
+	return &History{
+		history: w.window.Get("history"),
+	}
+}
 
-	for i := 0; i < l; i++ {
-		elems[i] = &element{
-			elem: e.Index(i),
-		}
+// MatchMedia returns a MediaQuery for the
+// given media query, e.g. "(max-width: 600px)"
+func (w *Win) MatchMedia(query string) *MediaQuery {
+	return &MediaQuery{
+		mql: w.window.Call("matchMedia", query),
 	}
+}
+
+// MediaQuery reports whether the
+// document matches a media query
+type MediaQuery struct {
+	mql js.Value
+}
+
+// Media returns the serialized media query
+func (m *MediaQuery) Media() string {
+	return m.mql.Get("media").String()
+}
+
+// Matches reports whether the document
+// currently matches the media query
+func (m *MediaQuery) Matches() bool {
+	return m.mql.Get("matches").Bool()
+}
+
+// OnChange calls fn whenever the document starts
+// or stops matching the media query, with whether
+// it now matches. The returned Subscription stops it
+func (m *MediaQuery) OnChange(fn func(matches bool), opts ...ListenerOptions) Subscription {
+	return listen(m.mql, "change", func(args ...js.Value) interface{} {
+		fn(args[0].Get("matches").Bool())
+		return nil
+	}, opts)
+}
+
+// AddEventListener allows you to add a custom event listener
+// to the window with the given name, configured by the first
+// of opts, if any are given. The returned Subscription
+// removes it
+func (w *Win) AddEventListener(name string, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription {
+	return listen(w.window, name, fn, opts)
+}
+
+// On adds an event listener to a specific "on" event,
+// like AddEventListener does
+func (w *Win) On(name OnEvent, fn func(args ...js.Value) interface{}, opts ...ListenerOptions) Subscription {
+	return listen(w.window, name.eventType(), fn, opts)
+}
+
+// OnKeyboardEvent adds a listener for KeyboardEvents, such
+// as OnKeyDown and OnKeyUp, to the window like On does
+func (w *Win) OnKeyboardEvent(name OnEvent, fn func(KeyboardEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(w.window, name, keyboardEvent, fn, opts)
+}
 
-	return elems
+// OnMouseEvent adds a listener for MouseEvents, such
+// as OnClick, OnMouseMove and OnContextMenu, to the window like On does
+func (w *Win) OnMouseEvent(name OnEvent, fn func(MouseEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(w.window, name, mouseEvent, fn, opts)
 }
 
-// On adds an event listener to a specific "on" event
-func (w *Win) On(name OnEvent, fn func(args ...js.Value) interface{}) {
-	w.window.Call("addEventListener", string(name), js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		return fn(args...)
-	}))
+// OnWheelEvent adds a listener for WheelEvents, such
+// as OnWheel, to the window like On does
+func (w *Win) OnWheelEvent(name OnEvent, fn func(WheelEvent), opts ...ListenerOptions) Subscription {
+	return listenTyped(w.window, name, wheelEvent, fn, opts)
 }
@@ -0,0 +1,80 @@
+//go:build js && wasm
+
+package forms
+
+import (
+	"syscall/js"
+)
+
+// jsControl is a control backed by
+// an element of a <form>
+type jsControl struct {
+	el js.Value
+}
+
+func asControls(controls []js.Value) []control {
+	cs := make([]control, len(controls))
+	for i := range controls {
+		cs[i] = jsControl{el: controls[i]}
+	}
+	return cs
+}
+
+func (c jsControl) controlType() string {
+	return controlType(c.el)
+}
+
+func (c jsControl) value() string {
+	return c.el.Get("value").String()
+}
+
+func (c jsControl) setValue(val string) {
+	c.el.Set("value", val)
+}
+
+func (c jsControl) checked() bool {
+	return c.el.Get("checked").Bool()
+}
+
+func (c jsControl) setChecked(checked bool) {
+	c.el.Set("checked", checked)
+}
+
+func (c jsControl) selected() []string {
+	var vals []string
+
+	options := c.el.Get("options")
+	for i := 0; i < options.Length(); i++ {
+		o := options.Index(i)
+		if o.Get("selected").Bool() {
+			vals = append(vals, o.Get("value").String())
+		}
+	}
+
+	return vals
+}
+
+func (c jsControl) setSelected(vals map[string]bool) {
+	options := c.el.Get("options")
+	for i := 0; i < options.Length(); i++ {
+		o := options.Index(i)
+		o.Set("selected", vals[o.Get("value").String()])
+	}
+}
+
+func (c jsControl) files() []File {
+	var files []File
+
+	list := c.el.Get("files")
+	for i := 0; i < list.Length(); i++ {
+		files = append(files, wrapFile(list.Index(i)))
+	}
+
+	return files
+}
+
+// controlType returns the type of a form control, e.g.
+// "text", "checkbox", "select-multiple" or "textarea"
+func controlType(control js.Value) string {
+	return control.Get("type").String()
+}
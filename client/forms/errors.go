@@ -0,0 +1,33 @@
+package forms
+
+import (
+	"sort"
+	"strings"
+)
+
+// Errors are validation errors, keyed
+// by the names of the fields they are for
+type Errors map[string]string
+
+func (e Errors) Error() string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = name + ": " + e[name]
+	}
+
+	return "forms: " + strings.Join(msgs, "; ")
+}
+
+// Validator is implemented by structs that validate
+// themselves after being read from a form. Validate
+// should return Errors for errors about fields, so
+// that they are shown next to them
+type Validator interface {
+	Validate() error
+}
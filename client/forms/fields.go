@@ -0,0 +1,299 @@
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// field is a struct field bound to
+// the form controls with its name
+type field struct {
+	name  string
+	index int
+	typ   reflect.Type
+}
+
+var fileType = reflect.TypeOf(File{})
+
+// fields returns the fields of t that are bound to
+// form controls, named by their "form" tags, or else
+// by their names. Fields tagged `form:"-"` and
+// unexported fields are skipped
+func fields(t reflect.Type) ([]field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("forms: cannot bind a %s, only a struct", t)
+	}
+
+	var fs []field
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Tag.Get("form")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		if !supported(f.Type) {
+			return nil, fmt.Errorf("forms: field %s has unsupported type %s", f.Name, f.Type)
+		}
+
+		fs = append(fs, field{
+			name:  name,
+			index: i,
+			typ:   f.Type,
+		})
+	}
+
+	return fs, nil
+}
+
+func supported(t reflect.Type) bool {
+	if t == fileType {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return true
+	case reflect.Slice:
+		return t.Elem() == fileType || scalar(t.Elem())
+	}
+
+	return scalar(t)
+}
+
+func scalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// control is a form control that
+// fields are filled into and read from
+type control interface {
+	// controlType returns the type of the control, e.g.
+	// "text", "checkbox", "select-multiple" or "textarea"
+	controlType() string
+	value() string
+	setValue(val string)
+	checked() bool
+	setChecked(checked bool)
+	// selected returns the values of the selected
+	// options of a select-multiple
+	selected() []string
+	// setSelected selects the options of a select-multiple
+	// whose values are in vals, and deselects the others
+	setSelected(vals map[string]bool)
+	// files returns the files chosen in a file input
+	files() []File
+}
+
+func checkable(c control) bool {
+	t := c.controlType()
+	return t == "checkbox" || t == "radio"
+}
+
+// fill sets the controls bound to f to val
+func (f field) fill(controls []control, val reflect.Value) {
+	switch {
+	case f.typ == fileType || f.typ.Kind() == reflect.Slice && f.typ.Elem() == fileType:
+		// file inputs can only be set by the user
+
+	case f.typ.Kind() == reflect.Bool:
+		for _, c := range controls {
+			if checkable(c) {
+				c.setChecked(val.Bool())
+				continue
+			}
+			c.setValue(strconv.FormatBool(val.Bool()))
+		}
+
+	case f.typ.Kind() == reflect.Slice:
+		vals := make([]string, val.Len())
+		set := make(map[string]bool)
+		for i := range vals {
+			vals[i] = format(val.Index(i))
+			set[vals[i]] = true
+		}
+
+		i := 0
+		for _, c := range controls {
+			switch {
+			case c.controlType() == "select-multiple":
+				c.setSelected(set)
+			case checkable(c):
+				c.setChecked(set[c.value()])
+			default:
+				// one text input per element
+				v := ""
+				if i < len(vals) {
+					v = vals[i]
+				}
+				c.setValue(v)
+				i++
+			}
+		}
+
+	default:
+		s := format(val)
+		for _, c := range controls {
+			if checkable(c) {
+				c.setChecked(c.value() == s)
+				continue
+			}
+			c.setValue(s)
+		}
+	}
+}
+
+// read returns the value of the controls bound to f,
+// or a message saying why it isn't a valid one
+func (f field) read(controls []control) (reflect.Value, string) {
+	switch {
+	case f.typ == fileType:
+		files := readFiles(controls)
+		if len(files) == 0 {
+			return reflect.ValueOf(File{}), ""
+		}
+		return reflect.ValueOf(files[0]), ""
+
+	case f.typ.Kind() == reflect.Slice && f.typ.Elem() == fileType:
+		return reflect.ValueOf(readFiles(controls)).Convert(f.typ), ""
+
+	case f.typ.Kind() == reflect.Bool:
+		for _, c := range controls {
+			if checkable(c) {
+				if c.checked() {
+					return reflect.ValueOf(true).Convert(f.typ), ""
+				}
+				continue
+			}
+
+			b, err := strconv.ParseBool(c.value())
+			if err != nil {
+				return reflect.Value{}, "must be true or false"
+			}
+			return reflect.ValueOf(b).Convert(f.typ), ""
+		}
+		return reflect.Zero(f.typ), ""
+
+	case f.typ.Kind() == reflect.Slice:
+		var raws []string
+		for _, c := range controls {
+			switch {
+			case c.controlType() == "select-multiple":
+				raws = append(raws, c.selected()...)
+			case checkable(c):
+				if c.checked() {
+					raws = append(raws, c.value())
+				}
+			default:
+				raws = append(raws, c.value())
+			}
+		}
+
+		vals := reflect.MakeSlice(f.typ, 0, len(raws))
+		for _, raw := range raws {
+			v, msg := parse(raw, f.typ.Elem())
+			if msg != "" {
+				return reflect.Value{}, msg
+			}
+			vals = reflect.Append(vals, v)
+		}
+		return vals, ""
+
+	default:
+		raw := ""
+		for _, c := range controls {
+			if checkable(c) {
+				if c.checked() {
+					raw = c.value()
+					break
+				}
+				continue
+			}
+			raw = c.value()
+			break
+		}
+		return parse(raw, f.typ)
+	}
+}
+
+func readFiles(controls []control) []File {
+	var files []File
+
+	for _, c := range controls {
+		if c.controlType() == "file" {
+			files = append(files, c.files()...)
+		}
+	}
+
+	return files
+}
+
+// parse parses raw into a t, or returns a
+// message saying why it can't. Empty numbers
+// are parsed as 0
+func parse(raw string, t reflect.Type) (reflect.Value, string) {
+	v := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+		return v, ""
+	}
+
+	if raw == "" {
+		return v, ""
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, t.Bits())
+		if err != nil {
+			return v, "must be a whole number"
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, t.Bits())
+		if err != nil {
+			return v, "must be a whole number of 0 or more"
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, t.Bits())
+		if err != nil {
+			return v, "must be a number"
+		}
+		v.SetFloat(n)
+	}
+
+	return v, ""
+}
+
+func format(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,186 @@
+package forms
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeControl is a control held in memory
+type fakeControl struct {
+	typ     string
+	val     string
+	check   bool
+	options map[string]bool
+	chosen  []File
+}
+
+func (c *fakeControl) controlType() string     { return c.typ }
+func (c *fakeControl) value() string           { return c.val }
+func (c *fakeControl) setValue(val string)     { c.val = val }
+func (c *fakeControl) checked() bool           { return c.check }
+func (c *fakeControl) setChecked(checked bool) { c.check = checked }
+func (c *fakeControl) files() []File           { return c.chosen }
+
+func (c *fakeControl) selected() []string {
+	var vals []string
+	for _, v := range []string{"a", "b", "c"} {
+		if c.options[v] {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+func (c *fakeControl) setSelected(vals map[string]bool) {
+	c.options = make(map[string]bool)
+	for _, v := range []string{"a", "b", "c"} {
+		c.options[v] = vals[v]
+	}
+}
+
+type signup struct {
+	Email   string `form:"email"`
+	Age     int    `form:"age"`
+	Agree   bool
+	Plan    string   `form:"plan"`
+	Tags    []string `form:"tags"`
+	Scores  []float64
+	Avatar  File
+	Ignored string `form:"-"`
+	secret  string
+}
+
+func fieldsByName(t *testing.T) map[string]field {
+	t.Helper()
+
+	fs, err := fields(reflect.TypeOf(signup{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byName := make(map[string]field, len(fs))
+	for _, f := range fs {
+		byName[f.name] = f
+	}
+	return byName
+}
+
+func TestFields(t *testing.T) {
+	byName := fieldsByName(t)
+
+	want := []string{"email", "age", "Agree", "plan", "tags", "Scores", "Avatar"}
+	if len(byName) != len(want) {
+		t.Errorf("expected %d fields, got %v", len(want), byName)
+	}
+	for _, name := range want {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("expected a field named %s", name)
+		}
+	}
+
+	if _, err := fields(reflect.TypeOf(struct{ M map[string]string }{})); err == nil {
+		t.Error("expected an error for an unsupported field")
+	}
+	if _, err := fields(reflect.TypeOf("")); err == nil {
+		t.Error("expected an error for a non-struct")
+	}
+}
+
+func TestFillAndRead(t *testing.T) {
+	byName := fieldsByName(t)
+
+	email := &fakeControl{typ: "email"}
+	age := &fakeControl{typ: "number"}
+	agree := &fakeControl{typ: "checkbox", val: "on"}
+	basic := &fakeControl{typ: "radio", val: "basic"}
+	pro := &fakeControl{typ: "radio", val: "pro"}
+	tags := &fakeControl{typ: "select-multiple"}
+	score1 := &fakeControl{typ: "text"}
+	score2 := &fakeControl{typ: "text"}
+	avatar := &fakeControl{typ: "file", chosen: []File{{Name: "me.png", Size: 3}}}
+
+	controls := map[string][]control{
+		"email":  {email},
+		"age":    {age},
+		"Agree":  {agree},
+		"plan":   {basic, pro},
+		"tags":   {tags},
+		"Scores": {score1, score2},
+		"Avatar": {avatar},
+	}
+
+	in := signup{
+		Email:  "a@b.c",
+		Age:    30,
+		Agree:  true,
+		Plan:   "pro",
+		Tags:   []string{"a", "c"},
+		Scores: []float64{1.5, 2},
+	}
+
+	v := reflect.ValueOf(in)
+	for name, f := range byName {
+		f.fill(controls[name], v.Field(f.index))
+	}
+
+	if email.val != "a@b.c" || age.val != "30" || !agree.check {
+		t.Errorf("expected scalars to be filled, got %q %q %v", email.val, age.val, agree.check)
+	}
+	if basic.check || !pro.check {
+		t.Errorf("expected the pro radio to be checked, got %v %v", basic.check, pro.check)
+	}
+	if !reflect.DeepEqual(tags.selected(), []string{"a", "c"}) {
+		t.Errorf("expected a and c to be selected, got %v", tags.selected())
+	}
+	if score1.val != "1.5" || score2.val != "2" {
+		t.Errorf("expected one input per score, got %q %q", score1.val, score2.val)
+	}
+
+	var out signup
+	o := reflect.ValueOf(&out).Elem()
+	for name, f := range byName {
+		val, msg := f.read(controls[name])
+		if msg != "" {
+			t.Fatalf("%s: %s", name, msg)
+		}
+		o.Field(f.index).Set(val)
+	}
+
+	in.Avatar = File{Name: "me.png", Size: 3}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("expected %+v, got %+v", in, out)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	byName := fieldsByName(t)
+
+	tests := []struct {
+		name string
+		raw  string
+		msg  string
+	}{
+		{"age", "thirty", "must be a whole number"},
+		{"age", "", ""},
+		{"Scores", "x", "must be a number"},
+	}
+
+	for _, tt := range tests {
+		_, msg := byName[tt.name].read([]control{&fakeControl{typ: "text", val: tt.raw}})
+		if msg != tt.msg {
+			t.Errorf("%s %q: expected %q, got %q", tt.name, tt.raw, tt.msg, msg)
+		}
+	}
+
+	if _, msg := parse("-1", reflect.TypeOf(uint(0))); msg == "" {
+		t.Error("expected a negative uint to be invalid")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	err := Errors{"name": "is required", "age": "must be a number"}
+
+	if got, want := err.Error(), "forms: age: must be a number; name: is required"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,16 @@
+package forms
+
+import (
+	"time"
+)
+
+// File is a file chosen in a file input. Bind
+// a File field to a single file input, and a
+// []File field to a multiple one
+type File struct {
+	Name         string
+	Type         string
+	Size         int
+	LastModified time.Time
+	file         jsFile
+}
@@ -0,0 +1,52 @@
+//go:build js && wasm
+
+package forms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/syke99/oasis/client/dom"
+	"syscall/js"
+	"time"
+)
+
+// jsFile is the JavaScript File a File wraps
+type jsFile = js.Value
+
+func wrapFile(file js.Value) File {
+	return File{
+		Name:         file.Get("name").String(),
+		Type:         file.Get("type").String(),
+		Size:         file.Get("size").Int(),
+		LastModified: time.UnixMilli(int64(file.Get("lastModified").Float())),
+		file:         file,
+	}
+}
+
+// JSValue returns the underlying JavaScript File,
+// e.g. for appending it to a FormData to upload
+// it, or undefined if no file was chosen
+func (f File) JSValue() js.Value {
+	return f.file
+}
+
+// Bytes reads the contents of the file. It blocks
+// until they have been read or ctx is done, so it
+// must be called from its own goroutine; see
+// dom.Await
+func (f File) Bytes(ctx context.Context) ([]byte, error) {
+	if f.file.IsUndefined() {
+		return nil, errors.New("forms: no file was chosen")
+	}
+
+	buf, err := dom.Await(ctx, f.file.Call("arrayBuffer"))
+	if err != nil {
+		return nil, fmt.Errorf("forms: %w", err)
+	}
+
+	b := make([]byte, buf.Get("byteLength").Int())
+	js.CopyBytesToGo(b, js.Global().Get("Uint8Array").New(buf))
+
+	return b, nil
+}
@@ -0,0 +1,8 @@
+//go:build !(js && wasm)
+
+package forms
+
+// jsFile stands in for the JavaScript File a File
+// wraps, so that forms can be built and tested
+// outside of the browser
+type jsFile struct{}
@@ -0,0 +1,297 @@
+//go:build js && wasm
+
+package forms
+
+import (
+	"errors"
+	"github.com/syke99/oasis/client/dom"
+	"reflect"
+	"sync"
+	"syscall/js"
+)
+
+const (
+	// ErrorForAttr marks the elements of a form that
+	// show the error of the field whose name is its
+	// value, e.g. <span data-error-for="email">
+	ErrorForAttr = "data-error-for"
+	// FormErrorAttr marks the elements of a form
+	// that show errors that aren't about any
+	// one field, e.g. <p data-form-error>
+	FormErrorAttr = "data-form-error"
+)
+
+// Form binds a struct to a <form> element. Each of the
+// struct's exported fields is bound to the controls with
+// its name, given by its "form" tag or else the field's
+// own name; fields tagged `form:"-"` aren't bound. Fields
+// may be strings, bools, numbers, slices of strings or
+// numbers, for multi-selects, checkbox groups or repeated
+// inputs, or Files and []Files, for file inputs. A bool is
+// bound to a checkbox, and any other field to a checkbox
+// or radio group by the values of its boxes
+type Form[T any] struct {
+	form    js.Value
+	val     *T
+	fields  []field
+	mu      sync.Mutex
+	touched map[string]bool
+}
+
+// New binds val to form and fills form's controls with
+// it. form's own validation is turned off, so that the
+// Form can report errors, including the browser's own,
+// like for a missing required field, itself
+func New[T any](form dom.Element, val *T) (*Form[T], error) {
+	fs, err := fields(reflect.TypeOf(val).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Form[T]{
+		form:    form.JSValue(),
+		val:     val,
+		fields:  fs,
+		touched: make(map[string]bool),
+	}
+
+	f.form.Set("noValidate", true)
+	f.Fill()
+
+	return f, nil
+}
+
+// Value returns the struct bound to the form
+func (f *Form[T]) Value() *T {
+	return f.val
+}
+
+// Fill sets the form's controls to the
+// values of the struct bound to it
+func (f *Form[T]) Fill() {
+	controls := f.controls()
+	v := reflect.ValueOf(f.val).Elem()
+
+	for _, fl := range f.fields {
+		if cs, ok := controls[fl.name]; ok {
+			fl.fill(asControls(cs), v.Field(fl.index))
+		}
+	}
+}
+
+// Read sets the fields of the struct bound to the
+// form to the values of its controls, then validates
+// it. It returns Errors for fields whose controls
+// are invalid, by the browser's checks or because
+// their values can't be parsed, which are left as
+// they were. Otherwise, if the struct is a
+// Validator, it returns its error
+func (f *Form[T]) Read() error {
+	controls := f.controls()
+	v := reflect.ValueOf(f.val).Elem()
+	errs := Errors{}
+
+	for _, fl := range f.fields {
+		cs, ok := controls[fl.name]
+		if !ok {
+			continue
+		}
+
+		if msg := validationMessage(cs); msg != "" {
+			errs[fl.name] = msg
+			continue
+		}
+
+		val, msg := fl.read(asControls(cs))
+		if msg != "" {
+			errs[fl.name] = msg
+			continue
+		}
+
+		v.Field(fl.index).Set(val)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if vd, ok := any(f.val).(Validator); ok {
+		return vd.Validate()
+	}
+
+	return nil
+}
+
+// OnInput reads the form whenever one of its controls
+// is changed, as with every keystroke, shows the errors
+// of the fields the user has changed so far, and calls
+// fn, which may be nil, with the struct and Read's error
+func (f *Form[T]) OnInput(fn func(val *T, err error), opts ...dom.ListenerOptions) dom.Subscription {
+	return f.onChange(dom.OnInput, fn, opts)
+}
+
+// OnChange works like OnInput, except for it reads the
+// form only once a change has been committed, as when
+// a text input loses focus
+func (f *Form[T]) OnChange(fn func(val *T, err error), opts ...dom.ListenerOptions) dom.Subscription {
+	return f.onChange(dom.OnChange, fn, opts)
+}
+
+func (f *Form[T]) onChange(name dom.OnEvent, fn func(val *T, err error), opts []dom.ListenerOptions) dom.Subscription {
+	return dom.Wrap(f.form).On(name, func(args ...js.Value) interface{} {
+		if n := args[0].Get("target").Get("name"); n.Type() == js.TypeString {
+			f.mu.Lock()
+			f.touched[n.String()] = true
+			f.mu.Unlock()
+		}
+
+		err := f.Read()
+		f.report(err, false)
+
+		if fn != nil {
+			fn(f.val, err)
+		}
+
+		return nil
+	}, opts...)
+}
+
+// OnSubmit keeps the form from being submitted by the
+// browser, and instead reads it, then calls fn with the
+// struct if it is valid. The errors of every field are
+// shown, along with any error fn returns, and the first
+// invalid control is focused. fn is called from the
+// page's event loop, so it must not block; it should
+// send the struct off from its own goroutine instead,
+// and pass any error to Report
+func (f *Form[T]) OnSubmit(fn func(val *T) error, opts ...dom.ListenerOptions) dom.Subscription {
+	return dom.Wrap(f.form).On(dom.OnSubmit, func(args ...js.Value) interface{} {
+		args[0].Call("preventDefault")
+
+		err := f.Read()
+		if err == nil {
+			err = fn(f.val)
+		}
+
+		f.Report(err)
+
+		return nil
+	}, opts...)
+}
+
+// Report shows err in the form, replacing any errors it
+// showed before, or clears them if err is nil. Errors are
+// shown for every field, in the elements marked with
+// ErrorForAttr, and any other error in the ones marked
+// with FormErrorAttr. Invalid controls are marked with
+// aria-invalid and their custom validity is set, and the
+// first of them is focused
+func (f *Form[T]) Report(err error) {
+	f.mu.Lock()
+	for _, fl := range f.fields {
+		f.touched[fl.name] = true
+	}
+	f.mu.Unlock()
+
+	f.report(err, true)
+
+	if first := f.form.Call("querySelector", "[aria-invalid=true]"); !first.IsNull() {
+		first.Call("focus")
+	}
+}
+
+// report shows err in the form. Unless all is true,
+// field errors are shown only for touched fields,
+// and other errors aren't shown at all
+func (f *Form[T]) report(err error, all bool) {
+	var errs Errors
+	formErr := ""
+
+	if err != nil && !errors.As(err, &errs) {
+		formErr = err.Error()
+	}
+	if !all {
+		formErr = ""
+	}
+
+	f.mu.Lock()
+	shown := make(map[string]string)
+	for name, msg := range errs {
+		if all || f.touched[name] {
+			shown[name] = msg
+		}
+	}
+	f.mu.Unlock()
+
+	for name, cs := range f.controls() {
+		msg := shown[name]
+
+		for _, c := range cs {
+			if c.Get("setCustomValidity").Truthy() {
+				c.Call("setCustomValidity", msg)
+			}
+
+			if msg == "" {
+				c.Call("removeAttribute", "aria-invalid")
+				continue
+			}
+			c.Call("setAttribute", "aria-invalid", "true")
+		}
+	}
+
+	hosts := f.form.Call("querySelectorAll", "["+ErrorForAttr+"]")
+	for i := 0; i < hosts.Length(); i++ {
+		h := hosts.Index(i)
+		h.Set("textContent", shown[h.Call("getAttribute", ErrorForAttr).String()])
+	}
+
+	hosts = f.form.Call("querySelectorAll", "["+FormErrorAttr+"]")
+	for i := 0; i < hosts.Length(); i++ {
+		hosts.Index(i).Set("textContent", formErr)
+	}
+}
+
+// controls returns the form's controls by name,
+// leaving out buttons, which hold no values
+func (f *Form[T]) controls() map[string][]js.Value {
+	controls := make(map[string][]js.Value)
+
+	elements := f.form.Get("elements")
+	for i := 0; i < elements.Length(); i++ {
+		c := elements.Index(i)
+
+		switch controlType(c) {
+		case "submit", "reset", "button", "image", "fieldset", "output":
+			continue
+		}
+
+		name := c.Get("name").String()
+		if name == "" {
+			continue
+		}
+
+		controls[name] = append(controls[name], c)
+	}
+
+	return controls
+}
+
+// validationMessage returns the browser's message
+// for the first of controls that fails its checks,
+// like for a missing required value, if any
+func validationMessage(controls []js.Value) string {
+	for _, c := range controls {
+		// clear the error Report set last time,
+		// so that only the browser's checks count
+		if c.Get("setCustomValidity").Truthy() {
+			c.Call("setCustomValidity", "")
+		}
+
+		validity := c.Get("validity")
+		if validity.Truthy() && !validity.Get("valid").Bool() {
+			return c.Get("validationMessage").String()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,204 @@
+//go:build js && wasm
+
+package client
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/dom"
+	"reflect"
+	"sync"
+	"syscall/js"
+)
+
+// errorKey is the property of the object a
+// function returns in place of a result when
+// it failed, so that the exported function
+// can throw it as a JavaScript Error
+const errorKey = "__oasisError"
+
+var (
+	jsValueType = reflect.TypeOf(js.Value{})
+	elementType = reflect.TypeOf((*dom.Element)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+
+	throwingOnce sync.Once
+	throwingShim js.Value
+)
+
+// Register adds fn to o's FuncMap under the given
+// name. Unlike with AddToFuncMap, fn can be any func,
+// such as
+//
+//	func(id int, name string) (User, error)
+//
+// Arguments passed from JavaScript are converted to
+// fn's parameter types through JSON, except for
+// js.Value and dom.Element parameters, which are
+// passed as they are. Missing arguments are passed
+// as zero values. fn may return nothing, a result,
+// an error, or a result and an error; results are
+// converted back to JavaScript through JSON, so
+// structs become objects, and a non-nil error is
+// thrown as a JavaScript Error. Register returns an
+// error if fn isn't a func with one of these shapes
+func (o *Oasis) Register(name string, fn any) error {
+	call, err := typedFunc(name, fn)
+	if err != nil {
+		return err
+	}
+
+	o.AddToFuncMap(name, func(args ...js.Value) interface{} {
+		res, err := call(args)
+		if err != nil {
+			return thrown(err)
+		}
+		return res
+	})
+
+	return nil
+}
+
+// typedFunc checks that fn can be registered and
+// returns a func that calls it with args converted
+// from JavaScript and returns its converted results
+func typedFunc(name string, fn any) (func(args []js.Value) (js.Value, error), error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return nil, fmt.Errorf("oasis: %s must be a func, got %T", name, fn)
+	}
+
+	t := v.Type()
+
+	hasResult := false
+	hasErr := false
+
+	switch t.NumOut() {
+	case 0:
+	case 1:
+		hasErr = t.Out(0) == errorType
+		hasResult = !hasErr
+	case 2:
+		if t.Out(1) != errorType {
+			return nil, fmt.Errorf("oasis: the second result of %s must be an error, got %s", name, t.Out(1))
+		}
+		hasResult = true
+		hasErr = true
+	default:
+		return nil, fmt.Errorf("oasis: %s must return at most a result and an error, got %d results", name, t.NumOut())
+	}
+
+	return func(args []js.Value) (js.Value, error) {
+		in, err := goArgs(t, args)
+		if err != nil {
+			return js.Undefined(), fmt.Errorf("%s: %w", name, err)
+		}
+
+		out := v.Call(in)
+
+		if hasErr {
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				return js.Undefined(), err
+			}
+		}
+
+		if !hasResult {
+			return js.Undefined(), nil
+		}
+
+		res, err := dom.ToJS(out[0].Interface())
+		if err != nil {
+			return js.Undefined(), fmt.Errorf("%s: %w", name, err)
+		}
+
+		return res, nil
+	}, nil
+}
+
+// goArgs converts args to the parameter types of
+// the func type t, spreading any extra args into
+// its variadic parameter if it has one
+func goArgs(t reflect.Type, args []js.Value) ([]reflect.Value, error) {
+	n := t.NumIn()
+	if t.IsVariadic() {
+		n--
+	}
+
+	in := make([]reflect.Value, 0, max(n, len(args)))
+
+	for i := 0; i < n; i++ {
+		arg := js.Undefined()
+		if i < len(args) {
+			arg = args[i]
+		}
+
+		val, err := goValue(t.In(i), arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i+1, err)
+		}
+
+		in = append(in, val)
+	}
+
+	if t.IsVariadic() {
+		elem := t.In(n).Elem()
+
+		for i := n; i < len(args); i++ {
+			val, err := goValue(elem, args[i])
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i+1, err)
+			}
+
+			in = append(in, val)
+		}
+	}
+
+	return in, nil
+}
+
+// goValue converts arg to a value of type t
+func goValue(t reflect.Type, arg js.Value) (reflect.Value, error) {
+	switch {
+	case t == jsValueType:
+		return reflect.ValueOf(arg), nil
+	case t == elementType:
+		val := reflect.New(t).Elem()
+		if arg.Type() == js.TypeObject {
+			val.Set(reflect.ValueOf(dom.Wrap(arg)))
+		}
+		return val, nil
+	}
+
+	val := reflect.New(t)
+
+	err := dom.DecodeJS(arg, val.Interface())
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot convert a JavaScript %s to %s: %w", arg.Type(), t, err)
+	}
+
+	return val.Elem(), nil
+}
+
+// thrown returns the object that makes an exported
+// function throw err as a JavaScript Error
+func thrown(err error) js.Value {
+	obj := js.Global().Get("Object").New()
+	obj.Set(errorKey, err.Error())
+	return obj
+}
+
+// throwing wraps fn in a JavaScript function that
+// throws the errors fn returns with thrown, since a
+// js.Func has no way of throwing them itself
+func throwing(fn js.Func) js.Value {
+	throwingOnce.Do(func() {
+		throwingShim = js.Global().Get("Function").New("fn", fmt.Sprintf(`return function(...args) {
+	const res = fn.apply(this, args);
+	if (res !== null && typeof res === "object" && %[1]q in res) {
+		throw new Error(res[%[1]q]);
+	}
+	return res;
+}`, errorKey))
+	})
+
+	return throwingShim.Invoke(fn)
+}
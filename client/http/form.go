@@ -0,0 +1,106 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"errors"
+	"github.com/syke99/oasis/client/dom"
+	"syscall/js"
+)
+
+// FormData wraps a JavaScript FormData object
+// to build multipart/form-data request bodies
+type FormData struct {
+	form js.Value
+}
+
+// NewFormData creates an empty *FormData
+func NewFormData() *FormData {
+	return &FormData{
+		form: js.Global().Get("FormData").New(),
+	}
+}
+
+// FormDataFrom creates a *FormData holding every
+// field of the given <form> Element, files included
+func FormDataFrom(form dom.Element) *FormData {
+	return &FormData{
+		form: js.Global().Get("FormData").New(form.JSValue()),
+	}
+}
+
+// Set sets the field name to value,
+// replacing any values it already had
+func (f *FormData) Set(name string, value string) *FormData {
+	f.form.Call("set", name, value)
+	return f
+}
+
+// Append adds value to the field name,
+// keeping any values it already had
+func (f *FormData) Append(name string, value string) *FormData {
+	f.form.Call("append", name, value)
+	return f
+}
+
+// AddFiles adds every file selected in input,
+// an <input type="file"> Element, to the field name
+func (f *FormData) AddFiles(name string, input dom.Element) *FormData {
+	files := input.JSValue().Get("files")
+	if files.IsNull() || files.IsUndefined() {
+		return f
+	}
+
+	for i := 0; i < files.Length(); i++ {
+		file := files.Index(i)
+		f.form.Call("append", name, file, file.Get("name"))
+	}
+
+	return f
+}
+
+// AddFile adds a file named filename with the
+// given contents and content type to the field name
+func (f *FormData) AddFile(name string, filename string, data []byte, contentType string) *FormData {
+	arr := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(arr, data)
+
+	opts := js.Global().Get("Object").New()
+	opts.Set("type", contentType)
+
+	blob := js.Global().Get("Blob").New([]any{arr}, opts)
+
+	f.form.Call("append", name, blob, filename)
+
+	return f
+}
+
+// JSValue returns the underlying
+// JavaScript FormData object
+func (f *FormData) JSValue() js.Value {
+	return f.form
+}
+
+func (f *FormData) body() *jsBody {
+	return &jsBody{
+		val: f.form,
+	}
+}
+
+// jsBody is a request body that is handed to
+// fetch as the JavaScript value it wraps
+type jsBody struct {
+	val js.Value
+}
+
+func (b *jsBody) Read([]byte) (int, error) {
+	return 0, errors.New("http: a JavaScript request body cannot be read from Go")
+}
+
+func (b *jsBody) Close() error {
+	return nil
+}
+
+func (b *jsBody) JSValue() js.Value {
+	return b.val
+}
@@ -0,0 +1,143 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// StatusError is returned by the helpers in this
+// package when the server responds with a status
+// code outside of the 2xx range
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Header     http.Header
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("http: %s", e.Status)
+	}
+	return fmt.Sprintf("http: %s: %s", e.Status, strings.TrimSpace(string(e.Body)))
+}
+
+// GetJSON performs a GET request to url and decodes
+// the JSON response into a T
+func GetJSON[T any](ctx context.Context, url string) (T, error) {
+	var out T
+
+	err := doJSON(ctx, http.MethodGet, url, nil, &out)
+
+	return out, err
+}
+
+// PostJSON performs a POST request to url with body
+// encoded as JSON and decodes the JSON response into
+// a T. If the response has no content, the zero value
+// of T is returned
+func PostJSON[T any](ctx context.Context, url string, body any) (T, error) {
+	var out T
+
+	err := doJSON(ctx, http.MethodPost, url, body, &out)
+
+	return out, err
+}
+
+// PostForm performs a POST request to url with data
+// URL-encoded as the body, like a submitted <form>
+func PostForm(ctx context.Context, url string, data url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return doChecked(req)
+}
+
+// Upload performs a POST request to url with form
+// as a multipart/form-data body, including any files
+// that were added to it. Use WithUploadProgress to
+// follow the progress of the upload
+func Upload(ctx context.Context, url string, form *FormData) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, form.body())
+	if err != nil {
+		return nil, err
+	}
+
+	// the browser sets the Content-Type, boundary included
+
+	return doChecked(req)
+}
+
+func doJSON(ctx context.Context, method string, url string, body any, out any) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, r)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := doChecked(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// doChecked performs req and returns a *StatusError
+// if the response's status code isn't in the 2xx range
+func doChecked(req *http.Request) (*http.Response, error) {
+	res, err := Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res, nil
+	}
+
+	defer res.Body.Close()
+
+	b, _ := io.ReadAll(res.Body)
+
+	return nil, &StatusError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Header:     res.Header,
+		Body:       b,
+	}
+}
@@ -3,130 +3,231 @@
 package http
 
 import (
-	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"github.com/syke99/oasis/client/dom"
 	"io"
 	"net/http"
+	"strconv"
 	"syscall/js"
 )
 
-// Do preforms the given *http.Request
-// and handles all asynchronicity to prevent
-// an Oasis from crashing due to blocking calls,
-// then returns the *http.Response or error; this
-// provides an idiomatic way of preforming HTTP
-// requests
+// DefaultClient is an *http.Client that performs
+// its requests with the browser's fetch API
+var DefaultClient = &http.Client{
+	Transport: defaultTransport,
+}
+
+// Do preforms the given *http.Request with
+// DefaultClient and blocks until the response
+// headers have been received, then returns the
+// *http.Response, whose Body is streamed as it
+// is read, or an error; this provides an idiomatic
+// way of preforming HTTP requests. Because it blocks,
+// Do must be called from its own goroutine; see
+// dom.Await
 func Do(req *http.Request) (*http.Response, error) {
-	var res *http.Response
-	var err error
-
-	// wrap HTTP request in try catch so is non-blocking
-	httpRequestAsHandler(req).
-		Invoke().
-		Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			res = goHttpResponse(args[0])
-			return nil
-		})).
-		Call("catch", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			err = errors.New(args[0].Call("toString").String())
-			return nil
-		}))
+	return DefaultClient.Do(req)
+}
+
+// Transport is an http.RoundTripper that performs
+// requests with the browser's fetch API, so that
+// stdlib clients can be used in an Oasis. Canceling
+// a request's context aborts it through an
+// AbortController. Like Do, RoundTrip must be called
+// from its own goroutine
+type Transport struct {
+	// Credentials is the fetch credentials mode: "omit",
+	// "same-origin" or "include". It defaults to "same-origin"
+	Credentials string
+	// Mode is the fetch request mode: "cors",
+	// "no-cors" or "same-origin". It defaults to "cors"
+	Mode string
+}
+
+// RoundTrip performs req with fetch
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if upload := uploadProgress(ctx); upload != nil {
+		return t.roundTripXHR(req, upload)
+	}
+
+	controller := js.Global().Get("AbortController").New()
+
+	opts, err := t.fetchOptions(req, controller.Get("signal"))
+	if err != nil {
+		return nil, err
+	}
 
+	response, err := dom.Await(ctx, js.Global().Call("fetch", req.URL.String(), opts))
 	if err != nil {
+		controller.Call("abort")
 		return nil, err
 	}
 
+	res := goHttpResponse(ctx, req, response, controller)
+
+	if download := downloadProgress(ctx); download != nil {
+		res.Body = &progressReader{
+			ReadCloser: res.Body,
+			fn:         download,
+			total:      res.ContentLength,
+		}
+	}
+
 	return res, nil
 }
 
-func goHttpResponse(response js.Value) *http.Response {
-	return &http.Response{
-		Status:     response.Get("statusText").String(),
-		StatusCode: response.Get("status").Int(),
-		Header:     httpResponseHeaders(response),
-		Body:       io.NopCloser(bytes.NewBuffer(httpResponseBody(response))),
+func (t *Transport) fetchOptions(req *http.Request, signal js.Value) (js.Value, error) {
+	opts := js.Global().Get("Object").New()
+
+	opts.Set("method", req.Method)
+	opts.Set("signal", signal)
+
+	credentials := t.Credentials
+	if credentials == "" {
+		credentials = "same-origin"
 	}
-}
+	opts.Set("credentials", credentials)
 
-func httpResponseHeaders(response js.Value) http.Header {
-	headers := make(http.Header)
+	if t.Mode != "" {
+		opts.Set("mode", t.Mode)
+	}
 
-	response.
-		Get("headers").
-		Call("forEach", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			key := args[0].String()
-			val := []string{args[1].String()}
+	headers := js.Global().Get("Headers").New()
+	for key, vals := range req.Header {
+		for _, val := range vals {
+			headers.Call("append", key, val)
+		}
+	}
+	opts.Set("headers", headers)
 
-			headers[key] = val
-			return nil
-		}))
+	if req.Body == nil || req.Body == http.NoBody {
+		return opts, nil
+	}
+	defer req.Body.Close()
 
-	return headers
+	if b, ok := req.Body.(JSBody); ok {
+		opts.Set("body", b.JSValue())
+		return opts, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		return js.Value{}, err
+	}
+
+	body := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(body, data)
+	opts.Set("body", body)
+
+	return opts, nil
 }
 
-func httpResponseBody(response js.Value) []byte {
-	var body []byte
+// JSBody is implemented by request bodies that are
+// JavaScript values, such as FormData or Blobs, so
+// that they can be passed to fetch as they are
+// instead of being read into bytes first
+type JSBody interface {
+	io.ReadCloser
+	JSValue() js.Value
+}
 
-	response.
-		Call("arrayBuffer").
-		Call("then", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-			l := this.Length()
+func goHttpResponse(ctx context.Context, req *http.Request, response js.Value, controller js.Value) *http.Response {
+	status := response.Get("status").Int()
+
+	res := &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, response.Get("statusText").String()),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        httpResponseHeaders(response),
+		ContentLength: -1,
+		Request:       req,
+	}
 
-			body = make([]byte, l)
+	if l, err := strconv.ParseInt(res.Header.Get("Content-Length"), 10, 64); err == nil {
+		res.ContentLength = l
+	}
+
+	body := response.Get("body")
+	if body.IsNull() || body.IsUndefined() {
+		res.Body = http.NoBody
+		return res
+	}
 
-			js.CopyBytesToGo(body, this)
-			return nil
-		}))
+	res.Body = &streamReader{
+		ctx:        ctx,
+		reader:     body.Call("getReader"),
+		controller: controller,
+	}
 
-	return body
+	return res
 }
 
-func httpRequestAsHandler(req *http.Request) js.Value {
-	// Handler for the Promise
-	// We need to return a Promise because HTTP requests are blocking in Go
-	handler := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-		resolve := args[0]
-		reject := args[1]
-
-		// Run this code asynchronously
-		go func() {
-			// Make the HTTP request
-			res, err := http.DefaultClient.Do(req)
-			if err != nil {
-				// Handle errors: reject the Promise if we have an error
-				errorConstructor := js.Global().Get("Error")
-				errorObject := errorConstructor.New(err.Error())
-				reject.Invoke(errorObject)
-				return
-			}
-			defer res.Body.Close()
-
-			// Read the response body
-			data, err := io.ReadAll(res.Body)
-			if err != nil {
-				// Handle errors here too
-				errorConstructor := js.Global().Get("Error")
-				errorObject := errorConstructor.New(err.Error())
-				reject.Invoke(errorObject)
-				return
-			}
-
-			// "data" is a byte slice, so we need to convert it to a JS Uint8Array object
-			arrayConstructor := js.Global().Get("Uint8Array")
-			dataJS := arrayConstructor.New(len(data))
-			js.CopyBytesToJS(dataJS, data)
-
-			// Create a Response object and pass the data
-			responseConstructor := js.Global().Get("Response")
-			response := responseConstructor.New(dataJS)
-
-			// Resolve the Promise
-			resolve.Invoke(response)
-		}()
-
-		// The handler of a Promise doesn't return any value
+func httpResponseHeaders(response js.Value) http.Header {
+	headers := make(http.Header)
+
+	forEach := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		// forEach passes the value first, then the key
+		headers.Add(http.CanonicalHeaderKey(args[1].String()), args[0].String())
 		return nil
 	})
+	defer forEach.Release()
+
+	response.Get("headers").Call("forEach", forEach)
+
+	return headers
+}
+
+// streamReader reads a response body from the
+// ReadableStream of a fetch Response, one chunk
+// at a time
+type streamReader struct {
+	ctx        context.Context
+	reader     js.Value
+	controller js.Value
+	pending    []byte
+	err        error
+}
 
-	return js.ValueOf(handler)
+func (s *streamReader) Read(p []byte) (int, error) {
+	if len(s.pending) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+
+		chunk, err := dom.Await(s.ctx, s.reader.Call("read"))
+		if err != nil {
+			s.controller.Call("abort")
+			s.err = err
+			return 0, err
+		}
+
+		if chunk.Get("done").Bool() {
+			s.err = io.EOF
+			return 0, io.EOF
+		}
+
+		value := chunk.Get("value")
+		s.pending = make([]byte, value.Get("length").Int())
+		js.CopyBytesToGo(s.pending, value)
+	}
+
+	n := copy(p, s.pending)
+	s.pending = s.pending[n:]
+
+	return n, nil
+}
+
+func (s *streamReader) Close() error {
+	if s.err == nil {
+		s.err = errors.New("http: read on closed response body")
+		s.reader.Call("cancel")
+	}
+	s.pending = nil
+	return nil
 }
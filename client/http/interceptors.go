@@ -0,0 +1,220 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"github.com/syke99/oasis/client/console"
+	"io"
+	"net/http"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// RoundTripFunc is an adapter to allow the use
+// of ordinary functions as http.RoundTrippers
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Interceptor sees every request made through the
+// http.RoundTripper it was chained onto. It can change
+// the request, inspect the response, or short-circuit
+// the request entirely; calling next continues on to
+// the rest of the chain
+type Interceptor func(req *http.Request, next http.RoundTripper) (*http.Response, error)
+
+// Chain returns an http.RoundTripper that passes each
+// request through interceptors, in the order given,
+// before performing it with rt
+func Chain(rt http.RoundTripper, interceptors ...Interceptor) http.RoundTripper {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := rt
+
+		rt = RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return interceptor(req, next)
+		})
+	}
+
+	return rt
+}
+
+// NewClient returns an *http.Client that performs its
+// requests with a *Transport, passing each of them
+// through interceptors first
+func NewClient(interceptors ...Interceptor) *http.Client {
+	return &http.Client{
+		Transport: Chain(&Transport{}, interceptors...),
+	}
+}
+
+// Use adds interceptors to DefaultClient, so that
+// they apply to Do and every helper in this package.
+// Interceptors added by earlier calls to Use see each
+// request first. It is safe to call while requests
+// are in flight; those keep the chain they started
+// with. Interceptors added with Use don't apply if
+// DefaultClient's Transport has been replaced
+func Use(interceptors ...Interceptor) {
+	defaultTransport.use(interceptors...)
+}
+
+// defaultTransport is the Transport of DefaultClient
+var defaultTransport = &sharedTransport{
+	base: &Transport{},
+	rt:   &Transport{},
+}
+
+// sharedTransport performs requests with a chain of
+// Interceptors that can be added to concurrently
+type sharedTransport struct {
+	mu           sync.RWMutex
+	base         http.RoundTripper
+	interceptors []Interceptor
+	rt           http.RoundTripper
+}
+
+// RoundTrip performs req with the current chain
+func (t *sharedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.RLock()
+	rt := t.rt
+	t.mu.RUnlock()
+
+	return rt.RoundTrip(req)
+}
+
+// use rebuilds the chain from every Interceptor added
+// so far, so that Use(a); Use(b) and Use(a, b) both
+// pass requests through a before b
+func (t *sharedTransport) use(interceptors ...Interceptor) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.interceptors = append(t.interceptors, interceptors...)
+	t.rt = Chain(t.base, t.interceptors...)
+}
+
+// SetHeader returns an Interceptor that sets the
+// header key to val on every request that doesn't
+// already have it
+func SetHeader(key string, val string) Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		if req.Header.Get(key) == "" {
+			req = req.Clone(req.Context())
+			req.Header.Set(key, val)
+		}
+		return next.RoundTrip(req)
+	}
+}
+
+// BearerAuth returns an Interceptor that authorizes
+// every request with the bearer token returned by
+// token, which is called once per request so that
+// refreshed tokens are picked up. Requests are sent
+// as they are if token returns an empty string
+func BearerAuth(token func() string) Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		if t := token(); t != "" {
+			req = req.Clone(req.Context())
+			req.Header.Set("Authorization", "Bearer "+t)
+		}
+		return next.RoundTrip(req)
+	}
+}
+
+// CSRF returns an Interceptor that sets the header
+// to the CSRF token found in the content attribute
+// of the page's <meta name="(metaName)"> element on
+// every request that isn't a GET, HEAD, OPTIONS or
+// TRACE request
+func CSRF(header string, metaName string) Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		if isSafe(req.Method) {
+			return next.RoundTrip(req)
+		}
+
+		meta := js.Global().Get("document").Call("querySelector", fmt.Sprintf(`meta[name="%s"]`, metaName))
+		if meta.IsNull() {
+			return next.RoundTrip(req)
+		}
+
+		req = req.Clone(req.Context())
+		req.Header.Set(header, meta.Call("getAttribute", "content").String())
+
+		return next.RoundTrip(req)
+	}
+}
+
+// Logger returns an Interceptor that logs the method,
+// URL, status and duration of every request to the
+// browser's console, and any errors as errors
+func Logger() Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		start := time.Now()
+
+		res, err := next.RoundTrip(req)
+		if err != nil {
+			console.ErrMessage(fmt.Sprintf("%s %s failed after %s: %s", req.Method, req.URL, time.Since(start), err.Error()), nil)
+			return nil, err
+		}
+
+		console.LogMessage(fmt.Sprintf("%s %s %d (%s)", req.Method, req.URL, res.StatusCode, time.Since(start)), nil)
+
+		return res, nil
+	}
+}
+
+// Timeout returns an Interceptor that cancels every
+// request that hasn't finished, response body included,
+// within d. A shorter deadline on a request's own
+// context still applies, so per-request timeouts can
+// be set with context.WithTimeout
+func Timeout(d time.Duration) Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		ctx, cancel := context.WithTimeout(req.Context(), d)
+
+		res, err := next.RoundTrip(req.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		res.Body = &cancelBody{
+			ReadCloser: res.Body,
+			cancel:     cancel,
+		}
+
+		return res, nil
+	}
+}
+
+// cancelBody cancels the context of the
+// request it belongs to once it is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelBody) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+func isSafe(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	return isSafe(method) || method == http.MethodPut || method == http.MethodDelete
+}
@@ -0,0 +1,47 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUseOrder(t *testing.T) {
+	var order []string
+
+	record := func(name string) Interceptor {
+		return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+			order = append(order, name)
+			return next.RoundTrip(req)
+		}
+	}
+
+	base := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "transport")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	separate := &sharedTransport{base: base, rt: base}
+	separate.use(record("a"))
+	separate.use(record("b"))
+
+	together := &sharedTransport{base: base, rt: base}
+	together.use(record("a"), record("b"))
+
+	want := []string{"a", "b", "transport"}
+
+	for name, rt := range map[string]*sharedTransport{"separate": separate, "together": together} {
+		order = nil
+
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		if _, err := rt.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(order, want) {
+			t.Errorf("%s: expected %v, got %v", name, want, order)
+		}
+	}
+}
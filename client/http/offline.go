@@ -0,0 +1,125 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/syke99/oasis/client/dom"
+	"net/http"
+	"sync"
+	"syscall/js"
+)
+
+// ErrOffline is returned by requests passed
+// through FailOffline while the browser is offline
+var ErrOffline = errors.New("http: the browser is offline")
+
+// network tracks whether the browser is online
+// through the window's online and offline events
+type network struct {
+	once   sync.Once
+	mu     sync.Mutex
+	online bool
+	// back is closed once the browser comes back
+	// online; it is nil while the browser is online
+	back chan struct{}
+}
+
+var connectivity = &network{}
+
+func (n *network) init() {
+	n.once.Do(func() {
+		n.setOnline(js.Global().Get("navigator").Get("onLine").Bool())
+
+		dom.Window.On(dom.OnOnline, func(args ...js.Value) interface{} {
+			n.setOnline(true)
+			return nil
+		})
+
+		dom.Window.On(dom.OnOffline, func(args ...js.Value) interface{} {
+			n.setOnline(false)
+			return nil
+		})
+	})
+}
+
+func (n *network) setOnline(online bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.online = online
+
+	switch {
+	case online && n.back != nil:
+		close(n.back)
+		n.back = nil
+	case !online && n.back == nil:
+		n.back = make(chan struct{})
+	}
+}
+
+// wait blocks until the browser is online or ctx is done
+func (n *network) wait(ctx context.Context) error {
+	n.init()
+
+	n.mu.Lock()
+	back := n.back
+	n.mu.Unlock()
+
+	if back == nil {
+		return nil
+	}
+
+	select {
+	case <-back:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsOnline reports whether the browser is online
+func IsOnline() bool {
+	connectivity.init()
+
+	connectivity.mu.Lock()
+	defer connectivity.mu.Unlock()
+
+	return connectivity.online
+}
+
+// WaitOnline blocks until the browser is
+// online, or returns ctx.Err() if ctx is
+// done first
+func WaitOnline(ctx context.Context) error {
+	return connectivity.wait(ctx)
+}
+
+// QueueOffline returns an Interceptor that holds
+// requests made while the browser is offline until it
+// comes back online, or until their context is done
+func QueueOffline() Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		if err := connectivity.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		return next.RoundTrip(req)
+	}
+}
+
+// FailOffline returns an Interceptor that fails
+// requests made while the browser is offline
+// with ErrOffline instead of sending them
+func FailOffline() Interceptor {
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		if !IsOnline() {
+			return nil, ErrOffline
+		}
+		return next.RoundTrip(req)
+	}
+}
+
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,190 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"syscall/js"
+)
+
+// ProgressFunc is called as a request's body is
+// uploaded or its response's body is downloaded
+// with the number of bytes transferred so far and
+// the total number of bytes, or -1 if unknown
+type ProgressFunc func(loaded int64, total int64)
+
+type progressKey int
+
+const (
+	uploadProgressKey progressKey = iota
+	downloadProgressKey
+)
+
+// WithUploadProgress returns a copy of ctx that makes
+// requests made with it report the progress of
+// uploading their bodies to fn. Since fetch can't
+// report upload progress, these requests are made
+// with XMLHttpRequest instead, and their responses
+// are only returned once fully downloaded
+func WithUploadProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, uploadProgressKey, fn)
+}
+
+// WithDownloadProgress returns a copy of ctx that
+// makes requests made with it report the progress
+// of downloading their response bodies to fn
+func WithDownloadProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, downloadProgressKey, fn)
+}
+
+func uploadProgress(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(uploadProgressKey).(ProgressFunc)
+	return fn
+}
+
+func downloadProgress(ctx context.Context) ProgressFunc {
+	fn, _ := ctx.Value(downloadProgressKey).(ProgressFunc)
+	return fn
+}
+
+// progressReader reports the progress of reading a
+// response body to fn as it is read
+type progressReader struct {
+	io.ReadCloser
+	fn     ProgressFunc
+	loaded int64
+	total  int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.ReadCloser.Read(b)
+	if n > 0 {
+		p.loaded += int64(n)
+		p.fn(p.loaded, p.total)
+	}
+	return n, err
+}
+
+// roundTripXHR performs req with XMLHttpRequest so that
+// the progress of uploading its body can be reported
+func (t *Transport) roundTripXHR(req *http.Request, upload ProgressFunc) (*http.Response, error) {
+	ctx := req.Context()
+
+	xhr := js.Global().Get("XMLHttpRequest").New()
+	xhr.Call("open", req.Method, req.URL.String(), true)
+	xhr.Set("responseType", "arraybuffer")
+	xhr.Set("withCredentials", t.Credentials == "include")
+
+	for key, vals := range req.Header {
+		for _, val := range vals {
+			xhr.Call("setRequestHeader", key, val)
+		}
+	}
+
+	body := js.Null()
+	if req.Body != nil && req.Body != http.NoBody {
+		if b, ok := req.Body.(JSBody); ok {
+			body = b.JSValue()
+		} else {
+			data, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			body = js.Global().Get("Uint8Array").New(len(data))
+			js.CopyBytesToJS(body, data)
+		}
+		req.Body.Close()
+	}
+
+	funcs := make([]js.Func, 0, 6)
+	defer func() {
+		for _, fn := range funcs {
+			fn.Release()
+		}
+	}()
+
+	on := func(target js.Value, event string, fn func(e js.Value)) {
+		f := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			fn(args[0])
+			return nil
+		})
+		funcs = append(funcs, f)
+		target.Call("addEventListener", event, f)
+	}
+
+	progress := func(fn ProgressFunc) func(e js.Value) {
+		return func(e js.Value) {
+			total := int64(-1)
+			if e.Get("lengthComputable").Bool() {
+				total = int64(e.Get("total").Float())
+			}
+			fn(int64(e.Get("loaded").Float()), total)
+		}
+	}
+
+	on(xhr.Get("upload"), "progress", progress(upload))
+
+	if download := downloadProgress(ctx); download != nil {
+		on(xhr, "progress", progress(download))
+	}
+
+	done := make(chan error, 1)
+
+	on(xhr, "load", func(js.Value) { done <- nil })
+	on(xhr, "error", func(js.Value) { done <- fmt.Errorf("http: request to %s failed", req.URL) })
+	on(xhr, "abort", func(js.Value) { done <- context.Canceled })
+	on(xhr, "timeout", func(js.Value) { done <- fmt.Errorf("http: request to %s timed out", req.URL) })
+
+	xhr.Call("send", body)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, err
+		}
+	case <-ctx.Done():
+		xhr.Call("abort")
+		<-done
+		return nil, ctx.Err()
+	}
+
+	data := make([]byte, 0)
+	if res := xhr.Get("response"); !res.IsNull() {
+		arr := js.Global().Get("Uint8Array").New(res)
+		data = make([]byte, arr.Get("length").Int())
+		js.CopyBytesToGo(data, arr)
+	}
+
+	status := xhr.Get("status").Int()
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, xhr.Get("statusText").String()),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        xhrHeaders(xhr.Call("getAllResponseHeaders").String()),
+		ContentLength: int64(len(data)),
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		Request:       req,
+	}, nil
+}
+
+func xhrHeaders(raw string) http.Header {
+	headers := make(http.Header)
+
+	for _, line := range strings.Split(raw, "\r\n") {
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		headers.Add(http.CanonicalHeaderKey(strings.TrimSpace(key)), strings.TrimSpace(val))
+	}
+
+	return headers
+}
@@ -0,0 +1,134 @@
+//go:build js && wasm
+
+package http
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures the Retry Interceptor
+type RetryPolicy struct {
+	// MaxAttempts is the most times a request is
+	// attempted, the first attempt included. It
+	// defaults to 3
+	MaxAttempts int
+	// BaseDelay is how long to wait before the first
+	// retry; every retry after it waits twice as long
+	// as the one before. It defaults to 200ms
+	BaseDelay time.Duration
+	// MaxDelay caps how long to wait between
+	// attempts. It defaults to 10s
+	MaxDelay time.Duration
+	// ShouldRetry reports whether an attempt that ended
+	// with res or err should be retried. It defaults to
+	// retrying errors other than context cancellations,
+	// and 429, 502, 503 and 504 responses
+	ShouldRetry func(res *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy is the RetryPolicy
+// used when none is given to Retry
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	ShouldRetry: shouldRetry,
+}
+
+// Retry returns an Interceptor that retries failed
+// requests with idempotent methods (GET, HEAD, OPTIONS,
+// TRACE, PUT and DELETE) following policy, waiting
+// longer before each attempt with exponential backoff
+// and jitter. A Retry-After header on the response
+// is honored. Requests with a body are only retried
+// if the body can be replayed through req.GetBody
+func Retry(policy RetryPolicy) Interceptor {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	if policy.ShouldRetry == nil {
+		policy.ShouldRetry = DefaultRetryPolicy.ShouldRetry
+	}
+
+	return func(req *http.Request, next http.RoundTripper) (*http.Response, error) {
+		canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if !isIdempotent(req.Method) || !canReplay {
+			return next.RoundTrip(req)
+		}
+
+		ctx := req.Context()
+
+		for attempt := 1; ; attempt++ {
+			if attempt > 1 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req = req.Clone(ctx)
+				req.Body = body
+			}
+
+			res, err := next.RoundTrip(req)
+
+			if attempt >= policy.MaxAttempts || !policy.ShouldRetry(res, err) {
+				return res, err
+			}
+
+			delay := policy.backoff(attempt, res)
+
+			if res != nil {
+				// drain so the connection can be reused
+				_, _ = io.Copy(io.Discard, res.Body)
+				res.Body.Close()
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+	}
+}
+
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, p.MaxDelay)
+		}
+	}
+
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	// jitter the delay to between half of it and all of it
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrOffline) && !isCanceled(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,95 @@
+//go:build js && wasm
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/syke99/oasis/client/console"
+	"github.com/syke99/oasis/client/dom"
+	"github.com/syke99/oasis/islands"
+	"syscall/js"
+)
+
+// hydratedKey is the property set on the root
+// element of an Island once it has been hydrated
+// so that it is never hydrated twice
+const hydratedKey = "__oasisHydrated"
+
+// HydrateFunc attaches Go behavior to the root
+// Element of a server-rendered interactive Island,
+// given the props it was rendered with
+type HydrateFunc func(root dom.Element, props map[string]any)
+
+// Hydrate registers fn to be called for every
+// interactive Island with the given name, both
+// when the Oasis starts running and whenever htmx
+// swaps new content into the page
+func (o *Oasis) Hydrate(name string, fn HydrateFunc) {
+	if o.hydrators == nil {
+		o.hydrators = make(map[string]HydrateFunc)
+	}
+
+	o.hydrators[name] = fn
+}
+
+// hydrate calls the registered HydrateFuncs for
+// every Island that hasn't been hydrated yet
+// inside of root, including root itself
+func (o *Oasis) hydrate(root js.Value) {
+	for name, fn := range o.hydrators {
+		selector := fmt.Sprintf(`[%s="%s"]`, islands.IslandAttr, name)
+
+		nodes := make([]js.Value, 0)
+
+		if root.Get("matches").Truthy() && root.Call("matches", selector).Bool() {
+			nodes = append(nodes, root)
+		}
+
+		found := root.Call("querySelectorAll", selector)
+		for i := 0; i < found.Length(); i++ {
+			nodes = append(nodes, found.Index(i))
+		}
+
+		for _, node := range nodes {
+			if node.Get(hydratedKey).Truthy() {
+				continue
+			}
+			node.Set(hydratedKey, true)
+
+			props := make(map[string]any)
+
+			if p := node.Call("getAttribute", islands.PropsAttr); !p.IsNull() {
+				err := json.Unmarshal([]byte(p.String()), &props)
+				if err != nil {
+					console.ErrMessage(fmt.Sprintf("could not read props of island %s: %s", name, err.Error()), nil)
+					continue
+				}
+			}
+
+			fn(dom.Wrap(node), props)
+		}
+	}
+}
+
+// listenForSwaps hydrates the Islands in content htmx
+// has loaded into the page. It returns the js.Func
+// listening for htmx:load so it can be released
+func (o *Oasis) listenForSwaps() js.Func {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return nil
+		}
+
+		elt := args[0].Get("detail").Get("elt")
+		if elt.Truthy() {
+			o.hydrate(elt)
+		}
+
+		return nil
+	})
+
+	js.Global().Get("document").Call("addEventListener", "htmx:load", fn)
+
+	return fn
+}
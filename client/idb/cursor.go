@@ -0,0 +1,204 @@
+//go:build js && wasm
+
+package idb
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// ErrStop can be returned by the func passed
+// to Each to stop iterating without an error
+var ErrStop = errors.New("idb: stop iterating")
+
+// KeyRange is a range of keys
+type KeyRange struct {
+	r js.Value
+}
+
+func (k *KeyRange) jsValue() js.Value {
+	if k == nil {
+		return js.Undefined()
+	}
+	return k.r
+}
+
+func keyRange(method string, args ...any) *KeyRange {
+	for i := range args {
+		if _, ok := args[i].(bool); ok {
+			continue
+		}
+		args[i], _ = toJS(args[i])
+	}
+
+	return &KeyRange{
+		r: js.Global().Get("IDBKeyRange").Call(method, args...),
+	}
+}
+
+// Only returns a KeyRange holding only key
+func Only(key any) *KeyRange {
+	return keyRange("only", key)
+}
+
+// Bound returns a KeyRange of the keys from lower to
+// upper, which exclude themselves if they are open
+func Bound(lower any, upper any, lowerOpen bool, upperOpen bool) *KeyRange {
+	return keyRange("bound", lower, upper, lowerOpen, upperOpen)
+}
+
+// LowerBound returns a KeyRange of the keys from
+// lower on, which excludes itself if it is open
+func LowerBound(lower any, open bool) *KeyRange {
+	return keyRange("lowerBound", lower, open)
+}
+
+// UpperBound returns a KeyRange of the keys up to
+// upper, which excludes itself if it is open
+func UpperBound(upper any, open bool) *KeyRange {
+	return keyRange("upperBound", upper, open)
+}
+
+// Direction is the order Each visits values in
+type Direction string
+
+const (
+	// Next visits values in ascending key order
+	Next Direction = "next"
+	// NextUnique visits values in ascending key order,
+	// skipping index keys it has already visited
+	NextUnique Direction = "nextunique"
+	// Prev visits values in descending key order
+	Prev Direction = "prev"
+	// PrevUnique visits values in descending key order,
+	// skipping index keys it has already visited
+	PrevUnique Direction = "prevunique"
+)
+
+// Cursor is the position of Each
+// in an object store or index
+type Cursor struct {
+	c  js.Value
+	tx *Tx
+}
+
+// Key returns the key of the current value,
+// which is its index key when iterating
+// over an index
+func (c *Cursor) Key() (any, error) {
+	return fromJS[any](c.c.Get("key"))
+}
+
+// PrimaryKey returns the key of the current
+// value in its object store
+func (c *Cursor) PrimaryKey() (any, error) {
+	return fromJS[any](c.c.Get("primaryKey"))
+}
+
+// Update replaces the current value with val
+func (c *Cursor) Update(val any) error {
+	v, err := toJS(val)
+	if err != nil {
+		return err
+	}
+
+	_, err = request(c.tx, func() js.Value {
+		return c.c.Call("update", v)
+	})
+
+	return err
+}
+
+// Delete deletes the current value
+func (c *Cursor) Delete() error {
+	_, err := request(c.tx, func() js.Value {
+		return c.c.Call("delete")
+	})
+
+	return err
+}
+
+// Each calls fn with every value in src, an object
+// store or an index, whose key is in rng, which may be
+// nil to visit every value, decoded into a T, in the
+// order given by dir. It stops at the first error fn
+// returns, and returns it, unless it is ErrStop
+func Each[T any](src Source, rng *KeyRange, dir Direction, fn func(c *Cursor, val T) error) error {
+	s, tx, err := src.source()
+	if err != nil {
+		return err
+	}
+
+	if dir == "" {
+		dir = Next
+	}
+
+	var req js.Value
+
+	err = catch(func() {
+		req = s.Call("openCursor", rng.jsValue(), string(dir))
+	})
+	if err != nil {
+		return err
+	}
+
+	// the request succeeds again each
+	// time the cursor is continued
+	ch := make(chan error, 1)
+
+	success := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ch <- nil
+		return nil
+	})
+
+	failure := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ch <- domError(req.Get("error"))
+		return nil
+	})
+
+	req.Set("onsuccess", success)
+	req.Set("onerror", failure)
+
+	defer func() {
+		req.Set("onsuccess", js.Null())
+		req.Set("onerror", js.Null())
+		success.Release()
+		failure.Release()
+	}()
+
+	for {
+		select {
+		case err = <-ch:
+		case <-tx.ctx.Done():
+			return tx.ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		c := req.Get("result")
+		if c.IsNull() {
+			return nil
+		}
+
+		val, err := fromJS[T](c.Get("value"))
+		if err != nil {
+			return err
+		}
+
+		err = fn(&Cursor{c: c, tx: tx}, val)
+		if errors.Is(err, ErrStop) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = catch(func() {
+			c.Call("continue")
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
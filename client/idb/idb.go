@@ -0,0 +1,456 @@
+//go:build js && wasm
+
+package idb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/syke99/oasis/client/dom"
+	"sync"
+	"syscall/js"
+)
+
+var (
+	// ErrUnavailable is returned by Open and
+	// DeleteDatabase when the browser doesn't
+	// provide IndexedDB
+	ErrUnavailable = errors.New("idb: IndexedDB is unavailable")
+	// ErrBlocked is returned, along with the context's
+	// error, by Open when its context is done while it
+	// waits for other tabs to close the database so it
+	// can be upgraded
+	ErrBlocked = errors.New("idb: the upgrade is blocked by open connections in other tabs")
+	// ErrCommitted is returned, along with fn's error,
+	// by Update and View when fn fails after the
+	// transaction has already committed itself, in
+	// which case its changes are kept
+	ErrCommitted = errors.New("idb: the transaction already committed")
+)
+
+// Error is an error reported by IndexedDB, such as
+// a ConstraintError when adding a value whose key
+// already exists
+type Error struct {
+	// Name is the name of the DOMException,
+	// e.g. "ConstraintError"
+	Name    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("idb: %s: %s", e.Name, e.Message)
+}
+
+// Migration upgrades a database's schema
+// from one version to the next
+type Migration func(u *Upgrade) error
+
+// DB is an open IndexedDB database
+type DB struct {
+	db            js.Value
+	versionChange js.Func
+	closeOnce     sync.Once
+}
+
+// Open opens the database with the given name, creating
+// it if it doesn't exist, and blocks until it is open.
+// The database's version is the number of migrations;
+// when opening it at a higher version than it was last
+// opened at, the migrations from its old version on are
+// run in order, e.g. only migrations[2] and migrations[3]
+// when opening version 4 of a version 2 database. If a
+// migration fails, the upgrade is rolled back and its error
+// returned. While other tabs keep an older version of the
+// database open, the upgrade waits for them to close it;
+// if ctx is done first, ErrBlocked is returned. Once open,
+// the database closes itself whenever another tab needs
+// to upgrade it. Like every blocking call in this
+// package, Open must be called from its own goroutine;
+// see dom.Await
+func Open(ctx context.Context, name string, migrations ...Migration) (*DB, error) {
+	factory := js.Global().Get("indexedDB")
+	if !factory.Truthy() {
+		return nil, ErrUnavailable
+	}
+
+	var req js.Value
+
+	err := catch(func() {
+		if len(migrations) == 0 {
+			req = factory.Call("open", name)
+			return
+		}
+		req = factory.Call("open", name, len(migrations))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var upgradeErr error
+
+	isBlocked := make(chan struct{})
+	var blockedOnce sync.Once
+
+	upgrade := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		u := &Upgrade{
+			db:         req.Get("result"),
+			tx:         req.Get("transaction"),
+			OldVersion: args[0].Get("oldVersion").Int(),
+			NewVersion: args[0].Get("newVersion").Int(),
+		}
+
+		for v := u.OldVersion; v < u.NewVersion; v++ {
+			err := catch(func() {
+				upgradeErr = migrations[v](u)
+			})
+			if err != nil {
+				upgradeErr = err
+			}
+
+			if upgradeErr != nil {
+				upgradeErr = fmt.Errorf("idb: migrating %s to version %d: %w", name, v+1, upgradeErr)
+				_ = catch(func() {
+					u.tx.Call("abort")
+				})
+				return nil
+			}
+		}
+
+		return nil
+	})
+	defer upgrade.Release()
+
+	req.Set("onupgradeneeded", upgrade)
+
+	blocked := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		blockedOnce.Do(func() {
+			close(isBlocked)
+		})
+		return nil
+	})
+	defer blocked.Release()
+
+	req.Set("onblocked", blocked)
+
+	res, err := wait(ctx, req)
+
+	req.Set("onupgradeneeded", js.Null())
+	req.Set("onblocked", js.Null())
+
+	if err != nil && err == ctx.Err() {
+		abandon(req)
+
+		select {
+		case <-isBlocked:
+			return nil, fmt.Errorf("%w: %w", ErrBlocked, err)
+		default:
+			return nil, err
+		}
+	}
+	if upgradeErr != nil {
+		return nil, upgradeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	db := &DB{
+		db: res,
+	}
+
+	db.versionChange = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		db.Close()
+		return nil
+	})
+	res.Set("onversionchange", db.versionChange)
+
+	return db, nil
+}
+
+// abandon makes sure an open request that is
+// given up on leaves nothing behind once it
+// finishes: any upgrade it would run is aborted,
+// and a database it opens is closed right away
+func abandon(req js.Value) {
+	if req.Get("readyState").String() == "done" {
+		if res := req.Get("result"); res.Truthy() {
+			res.Call("close")
+		}
+		return
+	}
+
+	var cleanup js.Func
+	cleanup = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		switch args[0].Get("type").String() {
+		case "upgradeneeded":
+			_ = catch(func() {
+				req.Get("transaction").Call("abort")
+			})
+			return nil
+		case "success":
+			req.Get("result").Call("close")
+		}
+
+		req.Set("onupgradeneeded", js.Null())
+		req.Set("onsuccess", js.Null())
+		req.Set("onerror", js.Null())
+		cleanup.Release()
+
+		return nil
+	})
+
+	req.Set("onupgradeneeded", cleanup)
+	req.Set("onsuccess", cleanup)
+	req.Set("onerror", cleanup)
+}
+
+// DeleteDatabase deletes the database with
+// the given name, and blocks until it has
+func DeleteDatabase(ctx context.Context, name string) error {
+	factory := js.Global().Get("indexedDB")
+	if !factory.Truthy() {
+		return ErrUnavailable
+	}
+
+	var req js.Value
+
+	err := catch(func() {
+		req = factory.Call("deleteDatabase", name)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = wait(ctx, req)
+
+	return err
+}
+
+// Name returns the name of the database
+func (db *DB) Name() string {
+	return db.db.Get("name").String()
+}
+
+// Version returns the version of the database
+func (db *DB) Version() int {
+	return db.db.Get("version").Int()
+}
+
+// Stores returns the names of
+// the database's object stores
+func (db *DB) Stores() []string {
+	return stringList(db.db.Get("objectStoreNames"))
+}
+
+// Close closes the database once
+// its transactions have finished
+func (db *DB) Close() {
+	db.closeOnce.Do(func() {
+		db.db.Call("close")
+		db.db.Set("onversionchange", js.Null())
+		db.versionChange.Release()
+	})
+}
+
+// Upgrade is passed to Migrations to
+// change the schema of a database
+type Upgrade struct {
+	db js.Value
+	tx js.Value
+	// OldVersion is the version the database was
+	// at before the upgrade, or 0 if it is new
+	OldVersion int
+	// NewVersion is the version the
+	// database is being upgraded to
+	NewVersion int
+}
+
+// StoreOptions configures a new object store
+type StoreOptions struct {
+	// KeyPath is the property of stored values that
+	// holds their keys, e.g. "id". If it is empty,
+	// keys are given separately from values
+	KeyPath string
+	// AutoIncrement generates keys for
+	// values that are stored without one
+	AutoIncrement bool
+}
+
+// IndexOptions configures a new index
+type IndexOptions struct {
+	// Unique keeps two values from
+	// having the same index key
+	Unique bool
+	// MultiEntry indexes each element of an
+	// array index key separately
+	MultiEntry bool
+}
+
+// Schema is the schema of an object
+// store during an Upgrade
+type Schema struct {
+	store js.Value
+}
+
+// CreateStore creates an object store
+func (u *Upgrade) CreateStore(name string, opts StoreOptions) (*Schema, error) {
+	o := js.Global().Get("Object").New()
+	if opts.KeyPath != "" {
+		o.Set("keyPath", opts.KeyPath)
+	}
+	o.Set("autoIncrement", opts.AutoIncrement)
+
+	s := &Schema{}
+
+	err := catch(func() {
+		s.store = u.db.Call("createObjectStore", name, o)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Store returns the schema of an object store
+// created by an earlier Migration
+func (u *Upgrade) Store(name string) (*Schema, error) {
+	s := &Schema{}
+
+	err := catch(func() {
+		s.store = u.tx.Call("objectStore", name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// DeleteStore deletes an object
+// store and everything in it
+func (u *Upgrade) DeleteStore(name string) error {
+	return catch(func() {
+		u.db.Call("deleteObjectStore", name)
+	})
+}
+
+// CreateIndex creates an index on the store
+// that looks values up by the property at keyPath
+func (s *Schema) CreateIndex(name string, keyPath string, opts IndexOptions) error {
+	o := js.Global().Get("Object").New()
+	o.Set("unique", opts.Unique)
+	o.Set("multiEntry", opts.MultiEntry)
+
+	return catch(func() {
+		s.store.Call("createIndex", name, keyPath, o)
+	})
+}
+
+// DeleteIndex deletes an index of the store
+func (s *Schema) DeleteIndex(name string) error {
+	return catch(func() {
+		s.store.Call("deleteIndex", name)
+	})
+}
+
+// wait blocks until req succeeds or fails, or ctx
+// is done, and returns the result of req
+func wait(ctx context.Context, req js.Value) (js.Value, error) {
+	ch := make(chan error, 1)
+
+	success := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ch <- nil
+		return nil
+	})
+
+	failure := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		ch <- domError(req.Get("error"))
+		return nil
+	})
+
+	req.Set("onsuccess", success)
+	req.Set("onerror", failure)
+
+	defer func() {
+		req.Set("onsuccess", js.Null())
+		req.Set("onerror", js.Null())
+		success.Release()
+		failure.Release()
+	}()
+
+	select {
+	case err := <-ch:
+		if err != nil {
+			return js.Undefined(), err
+		}
+		return req.Get("result"), nil
+	case <-ctx.Done():
+		return js.Undefined(), ctx.Err()
+	}
+}
+
+// catch calls fn and returns any exception
+// JavaScript threw while it ran as an error
+func catch(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, ok := r.(js.Error)
+			if !ok {
+				panic(r)
+			}
+			err = domError(jsErr.Value)
+		}
+	}()
+
+	fn()
+
+	return nil
+}
+
+func domError(val js.Value) error {
+	if val.IsNull() || val.IsUndefined() {
+		return &Error{
+			Name:    "AbortError",
+			Message: "the transaction was aborted",
+		}
+	}
+
+	return &Error{
+		Name:    val.Get("name").String(),
+		Message: val.Get("message").String(),
+	}
+}
+
+func stringList(list js.Value) []string {
+	strs := make([]string, list.Length())
+
+	for i := range strs {
+		strs[i] = list.Index(i).String()
+	}
+
+	return strs
+}
+
+func toJS(val any) (js.Value, error) {
+	if k, ok := val.(*KeyRange); ok {
+		return k.jsValue(), nil
+	}
+
+	v, err := dom.ToJS(val)
+	if err != nil {
+		return v, fmt.Errorf("idb: %w", err)
+	}
+
+	return v, nil
+}
+
+func fromJS[T any](val js.Value) (T, error) {
+	v, err := dom.FromJS[T](val)
+	if err != nil {
+		return v, fmt.Errorf("idb: %w", err)
+	}
+
+	return v, nil
+}
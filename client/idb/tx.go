@@ -0,0 +1,368 @@
+//go:build js && wasm
+
+package idb
+
+import (
+	"context"
+	"fmt"
+	"syscall/js"
+)
+
+// Tx is a transaction over one
+// or more of a DB's object stores
+type Tx struct {
+	tx  js.Value
+	ctx context.Context
+}
+
+// Update runs fn in a read-write transaction over the
+// given object stores, and blocks until the transaction
+// has been committed. If fn returns an error, or any
+// request made in it fails, the transaction is aborted,
+// so that none of its changes are kept, and the error
+// is returned. The transaction commits itself once fn
+// stops making requests, so fn must not wait on anything
+// else, such as an HTTP request, in between them. If it
+// does and then fails, the changes it made before are
+// kept, and ErrCommitted is returned along with its error
+func (db *DB) Update(ctx context.Context, stores []string, fn func(tx *Tx) error) error {
+	return db.run(ctx, "readwrite", stores, fn)
+}
+
+// View works like Update, except for it runs fn
+// in a read-only transaction
+func (db *DB) View(ctx context.Context, stores []string, fn func(tx *Tx) error) error {
+	return db.run(ctx, "readonly", stores, fn)
+}
+
+func (db *DB) run(ctx context.Context, mode string, stores []string, fn func(tx *Tx) error) error {
+	names := make([]any, len(stores))
+	for i := range stores {
+		names[i] = stores[i]
+	}
+
+	var tx js.Value
+
+	err := catch(func() {
+		tx = db.db.Call("transaction", names, mode)
+	})
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+
+	finish := func(err error) {
+		select {
+		case done <- err:
+		default:
+		}
+	}
+
+	complete := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		finish(nil)
+		return nil
+	})
+
+	abort := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		finish(domError(tx.Get("error")))
+		return nil
+	})
+
+	tx.Set("oncomplete", complete)
+	tx.Set("onabort", abort)
+
+	defer func() {
+		tx.Set("oncomplete", js.Null())
+		tx.Set("onabort", js.Null())
+		complete.Release()
+		abort.Release()
+	}()
+
+	t := &Tx{
+		tx:  tx,
+		ctx: ctx,
+	}
+
+	err = fn(t)
+	if err != nil {
+		t.Abort()
+
+		// the transaction only completes if it had
+		// already committed itself before the abort
+		if <-done == nil {
+			return fmt.Errorf("%w: %w", ErrCommitted, err)
+		}
+
+		return err
+	}
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		t.Abort()
+		return ctx.Err()
+	}
+}
+
+// Abort aborts the transaction, rolling back
+// every change made in it. Requests made in it
+// afterwards fail
+func (t *Tx) Abort() {
+	// aborting a finished transaction throws,
+	// but there is nothing left to abort then
+	_ = catch(func() {
+		t.tx.Call("abort")
+	})
+}
+
+// Store returns the object store with the given
+// name, which must be one of the stores the
+// transaction was started over
+func (t *Tx) Store(name string) *Store {
+	s := &Store{
+		tx: t,
+	}
+
+	s.err = catch(func() {
+		s.store = t.tx.Call("objectStore", name)
+	})
+
+	return s
+}
+
+// Source is an object store or an index that
+// values can be read from with Get, GetAll
+// and Each
+type Source interface {
+	source() (js.Value, *Tx, error)
+}
+
+// Store is an object store within a Tx
+type Store struct {
+	store js.Value
+	tx    *Tx
+	err   error
+}
+
+func (s *Store) source() (js.Value, *Tx, error) {
+	return s.store, s.tx, s.err
+}
+
+// Put stores val, encoded as JSON, replacing any
+// value with the same key, and returns its key.
+// key must be given if the store has no KeyPath
+// and doesn't AutoIncrement, and must not be
+// given otherwise
+func (s *Store) Put(val any, key ...any) (any, error) {
+	return s.write("put", val, key)
+}
+
+// Add works like Put, except for it fails with a
+// ConstraintError if a value with the key exists
+func (s *Store) Add(val any, key ...any) (any, error) {
+	return s.write("add", val, key)
+}
+
+func (s *Store) write(method string, val any, key []any) (any, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	v, err := toJS(val)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []any{v}
+
+	if len(key) > 0 {
+		k, err := toJS(key[0])
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, k)
+	}
+
+	res, err := s.request(func() js.Value {
+		return s.store.Call(method, args...)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fromJS[any](res)
+}
+
+// Delete deletes the value with the given key, or
+// every value in the key, if it is a *KeyRange
+func (s *Store) Delete(key any) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	k, err := toJS(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.request(func() js.Value {
+		return s.store.Call("delete", k)
+	})
+
+	return err
+}
+
+// Clear deletes every value in the store
+func (s *Store) Clear() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	_, err := s.request(func() js.Value {
+		return s.store.Call("clear")
+	})
+
+	return err
+}
+
+// Count returns the number of values in
+// the store that are in rng, which may be
+// nil to count every value
+func (s *Store) Count(rng *KeyRange) (int, error) {
+	return count(s, rng)
+}
+
+// Index returns the index of the
+// store with the given name
+func (s *Store) Index(name string) *Index {
+	i := &Index{
+		tx:  s.tx,
+		err: s.err,
+	}
+
+	if i.err == nil {
+		i.err = catch(func() {
+			i.index = s.store.Call("index", name)
+		})
+	}
+
+	return i
+}
+
+func (s *Store) request(call func() js.Value) (js.Value, error) {
+	return request(s.tx, call)
+}
+
+// Index is an index of an object store within a
+// Tx, which looks values up by their index keys
+type Index struct {
+	index js.Value
+	tx    *Tx
+	err   error
+}
+
+func (i *Index) source() (js.Value, *Tx, error) {
+	return i.index, i.tx, i.err
+}
+
+// Count returns the number of values whose
+// index keys are in rng, which may be nil
+// to count every value
+func (i *Index) Count(rng *KeyRange) (int, error) {
+	return count(i, rng)
+}
+
+// Get returns the value with the given key in src, an
+// object store or an index, decoded into a T, and
+// reports whether there was one. If src is an index,
+// key may be a *KeyRange, and the first value with an
+// index key in it is returned
+func Get[T any](src Source, key any) (T, bool, error) {
+	var val T
+
+	s, tx, err := src.source()
+	if err != nil {
+		return val, false, err
+	}
+
+	k, err := toJS(key)
+	if err != nil {
+		return val, false, err
+	}
+
+	res, err := request(tx, func() js.Value {
+		return s.Call("get", k)
+	})
+	if err != nil {
+		return val, false, err
+	}
+
+	if res.IsUndefined() {
+		return val, false, nil
+	}
+
+	val, err = fromJS[T](res)
+
+	return val, true, err
+}
+
+// GetAll returns every value in src whose key is
+// in rng, which may be nil to return every value,
+// in key order, decoded into Ts
+func GetAll[T any](src Source, rng *KeyRange) ([]T, error) {
+	s, tx, err := src.source()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := request(tx, func() js.Value {
+		return s.Call("getAll", rng.jsValue())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make([]T, res.Length())
+
+	for i := range vals {
+		vals[i], err = fromJS[T](res.Index(i))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vals, nil
+}
+
+func count(src Source, rng *KeyRange) (int, error) {
+	s, tx, err := src.source()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := request(tx, func() js.Value {
+		return s.Call("count", rng.jsValue())
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Int(), nil
+}
+
+// request makes the request returned
+// by call in tx and waits for its result
+func request(tx *Tx, call func() js.Value) (js.Value, error) {
+	var req js.Value
+
+	err := catch(func() {
+		req = call()
+	})
+	if err != nil {
+		return js.Undefined(), err
+	}
+
+	return wait(tx.ctx, req)
+}
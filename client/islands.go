@@ -0,0 +1,53 @@
+//go:build js && wasm
+
+package client
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/dom"
+	"github.com/syke99/oasis/islands"
+	"syscall/js"
+)
+
+// RenderInto renders island in the client, exactly
+// as the server would, and replaces the content of
+// el with it, without a round trip to the server
+func RenderInto(el dom.Element, island islands.Island) error {
+	str, err := island.Render()
+	if err != nil {
+		return err
+	}
+
+	el.SetInnerHTML(str)
+
+	return nil
+}
+
+// Rerender renders island in the client and swaps
+// it in for root, the root Element of the same Island
+// rendered earlier, e.g. to optimistically show the
+// result of an action before the server confirms it.
+// The new root Element is hydrated if island is
+// interactive and then returned
+func (o *Oasis) Rerender(root dom.Element, island islands.Island) (dom.Element, error) {
+	str, err := island.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := js.Global().Get("document").Call("createElement", "template")
+	tmpl.Set("innerHTML", str)
+
+	newRoot := tmpl.Get("content").Get("firstElementChild")
+	if newRoot.IsNull() {
+		return nil, fmt.Errorf("island %s did not render an element", island.GetName())
+	}
+
+	root.ReplaceWith(dom.Wrap(newRoot))
+
+	if o.hydrators != nil {
+		o.hydrate(newRoot)
+	}
+
+	return dom.Wrap(newRoot), nil
+}
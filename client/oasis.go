@@ -3,25 +3,43 @@
 package client
 
 import (
+	"context"
 	"github.com/syke99/oasis/client/console"
+	"strings"
+	"sync"
 	"syscall/js"
 )
 
 // FuncMap is a map of functions that you
 // want available for being called inside
-// of HTML
+// of HTML. Functions with other signatures
+// can be added with Oasis.Register
 type FuncMap map[string]func(args ...js.Value) interface{}
 
 // Oasis is used for configuring the client's
 // available WASM functionality
 type Oasis struct {
-	funcs FuncMap
+	funcs     FuncMap
+	hydrators map[string]HydrateFunc
+	namespace string
+	stopInit  sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
 }
 
 func NewOasis() *Oasis {
 	return &Oasis{}
 }
 
+// SetNamespace makes o export its functions onto the
+// global object at the given dot-separated path, e.g.
+// "oasis" or "app.oasis", creating any objects along
+// it that don't exist yet, instead of onto the global
+// object itself. It must be called before running o
+func (o *Oasis) SetNamespace(namespace string) {
+	o.namespace = namespace
+}
+
 // AddToFuncMap adds a function with the given name
 // to o. If o doesn't have any functions added yet,
 // it will create an underlying map to hold the
@@ -47,23 +65,135 @@ func (o *Oasis) AddFuncMap(fmap FuncMap) {
 	}
 }
 
-// Run should be called after your Oasis
+// Run runs o until Stop is called; see RunContext
+func (o *Oasis) Run() {
+	o.RunContext(context.Background())
+}
+
+// RunContext should be called after your Oasis
 // has been configured. This will register
 // all funcs in the FuncMap held by the
 // Oasis and make them available to be used
 // as functions for values of attributes
-// of HTML elements. It will log an error
-// if no functions were added to the FuncMap
-// before being called and then exit
-func (o *Oasis) Run() {
-	if o.funcs != nil {
-		for k, v := range o.funcs {
-			js.Global().Set(k, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
-				return v(args...)
-			}))
+// of HTML elements, then hydrate every
+// interactive Island on the page and in any
+// content htmx swaps in later. Once it has, it
+// dispatches ReadyEvent and resolves ReadyPromise,
+// then blocks until ctx is done or Stop is called.
+// Before returning, it removes the functions it
+// exported and releases everything it registered
+// with JavaScript. It will log an error if neither
+// functions nor HydrateFuncs were added before
+// being called and then exit
+func (o *Oasis) RunContext(ctx context.Context) {
+	if o.funcs == nil && o.hydrators == nil {
+		console.ErrMessage("attempted to run oasis app without funcmap; shutting down", nil)
+		return
+	}
+
+	target := o.exportTarget()
+
+	exported := make([]js.Func, 0, len(o.funcs))
+
+	for name, fn := range o.funcs {
+		exported = append(exported, o.export(target, name, fn))
+	}
+
+	defer func() {
+		for name := range o.funcs {
+			target.Delete(name)
 		}
+		for _, fn := range exported {
+			fn.Release()
+		}
+	}()
+
+	if o.hydrators != nil {
+		document := js.Global().Get("document")
+
+		o.hydrate(document)
+
+		listener := o.listenForSwaps()
+		defer func() {
+			document.Call("removeEventListener", "htmx:load", listener)
+			listener.Release()
+		}()
+	}
+
+	o.ready(target)
+	defer js.Global().Delete(ReadyPromise)
+
+	select {
+	case <-ctx.Done():
+	case <-o.stopped():
+	}
+}
+
+// Stop makes a running Oasis remove its
+// functions, release them and return
+func (o *Oasis) Stop() {
+	stop := o.stopped()
+	o.stopOnce.Do(func() {
+		close(stop)
+	})
+}
+
+func (o *Oasis) stopped() chan struct{} {
+	o.stopInit.Do(func() {
+		o.stop = make(chan struct{})
+	})
+	return o.stop
+}
+
+// export sets fn as name on target and returns
+// the js.Func it was wrapped in so that it can
+// be released
+func (o *Oasis) export(target js.Value, name string, fn func(args ...js.Value) interface{}) js.Func {
+	f := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		return fn(args...)
+	})
+
+	target.Set(name, throwing(f))
+
+	return f
+}
+
+// exportTarget returns the object o's functions
+// are exported onto
+func (o *Oasis) exportTarget() js.Value {
+	target := js.Global()
+	if o.namespace == "" {
+		return target
+	}
+
+	for _, part := range strings.Split(o.namespace, ".") {
+		next := target.Get(part)
+		if next.Type() != js.TypeObject {
+			next = js.Global().Get("Object").New()
+			target.Set(part, next)
+		}
+		target = next
+	}
+
+	return target
+}
+
+// ready resolves ReadyPromise and dispatches
+// ReadyEvent with target as their values. If
+// ReadyScript already created ReadyPromise, it
+// is resolved instead of replaced
+func (o *Oasis) ready(target js.Value) {
+	if resolve := js.Global().Get(readyResolve); resolve.Type() == js.TypeFunction {
+		resolve.Invoke(target)
+		js.Global().Delete(readyResolve)
 	} else {
-		console.ErrMessage("attempted to run oasis app without funcmap; shutting down", nil)
+		js.Global().Set(ReadyPromise, js.Global().Get("Promise").Call("resolve", target))
 	}
-	<-make(chan struct{})
+
+	opts := js.Global().Get("Object").New()
+	opts.Set("detail", target)
+
+	event := js.Global().Get("CustomEvent").New(ReadyEvent, opts)
+
+	js.Global().Get("document").Call("dispatchEvent", event)
 }
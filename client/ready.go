@@ -0,0 +1,37 @@
+package client
+
+const (
+	// ReadyEvent is the name of the event dispatched
+	// on the document once an Oasis has exported its
+	// functions and hydrated the page. Its detail is
+	// the object the functions were exported onto.
+	// Listeners added after it was dispatched never
+	// see it, so scripts that may run late should
+	// wait on ReadyPromise instead
+	ReadyEvent = "oasis:ready"
+	// ReadyPromise is the name of the global Promise
+	// that resolves, with the same value as ReadyEvent's
+	// detail, once an Oasis is ready. Unless the page
+	// runs ReadyScript before loading the WASM, it only
+	// exists once the Oasis is ready
+	ReadyPromise = "oasisReady"
+	// readyResolve is the name of the global function
+	// ReadyScript creates to resolve ReadyPromise
+	readyResolve = "__oasisResolveReady"
+)
+
+// ReadyScript is JavaScript that creates ReadyPromise
+// before the WASM has loaded, so that scripts can wait
+// on it from the moment the page starts. Run it from an
+// inline script ahead of the one that loads the WASM,
+// e.g. with an Island's AddScript or
+//
+//	<script>{{ .readyScript }}</script>
+//
+// in a layout given client.ReadyScript as template.JS
+const ReadyScript = `(function () {
+	if (window.` + ReadyPromise + `) return;
+	window.` + ReadyPromise + ` = new Promise(function (resolve) {
+		window.` + readyResolve + ` = resolve;
+	});
+})();`
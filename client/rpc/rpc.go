@@ -0,0 +1,182 @@
+//go:build js && wasm
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	oasisHttp "github.com/syke99/oasis/client/http"
+	"github.com/syke99/oasis/internal"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Error is returned by a stub when calling a
+// method of an RPC interface failed, either
+// because the method itself returned an error
+// or because the call couldn't be made
+type Error struct {
+	Method     string
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc: %s: %s", e.Method, e.Message)
+}
+
+// Bind fills in stub, a pointer to a struct whose fields
+// are funcs named and typed after methods of the interface
+// I, so that calling a field calls the matching method of
+// the Endpoints the server created for I with
+// server.RPCEndpoints at baseURL. Given
+//
+//	type Users interface {
+//		Get(ctx context.Context, id int) (User, error)
+//	}
+//
+// a stub would be
+//
+//	type UsersStub struct {
+//		Get func(ctx context.Context, id int) (User, error)
+//	}
+//
+// Fields that aren't funcs are ignored. Calls block
+// until the server responds, so they must not be made
+// directly from a function called by JavaScript
+func Bind[I any](baseURL string, stub any) error {
+	methods, err := internal.RPCMethods(reflect.TypeOf((*I)(nil)).Elem())
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]internal.RPCMethod, len(methods))
+	for _, m := range methods {
+		byName[m.Name] = m
+	}
+
+	v := reflect.ValueOf(stub)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("rpc: stub must be a pointer to a struct, got %T", stub)
+	}
+
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Type.Kind() != reflect.Func || !f.IsExported() {
+			continue
+		}
+
+		m, ok := byName[f.Name]
+		if !ok {
+			return fmt.Errorf("rpc: %s has no method %s", reflect.TypeOf((*I)(nil)).Elem(), f.Name)
+		}
+
+		if f.Type != m.Type {
+			return fmt.Errorf("rpc: field %s of %s is a %s, but the method is a %s", f.Name, t, f.Type, m.Type)
+		}
+
+		url := strings.TrimSuffix(baseURL, "/") + "/" + m.Name
+
+		v.Field(i).Set(reflect.MakeFunc(f.Type, func(args []reflect.Value) []reflect.Value {
+			return call(url, m, args)
+		}))
+	}
+
+	return nil
+}
+
+func call(url string, m internal.RPCMethod, args []reflect.Value) []reflect.Value {
+	ctx := context.Background()
+	if m.HasContext {
+		if c, ok := args[0].Interface().(context.Context); ok && c != nil {
+			ctx = c
+		}
+		args = args[1:]
+	}
+
+	res, err := do(ctx, url, m, args)
+
+	out := make([]reflect.Value, 0, 2)
+	if m.Result != nil {
+		out = append(out, res)
+	}
+
+	errVal := reflect.New(reflect.TypeOf((*error)(nil)).Elem()).Elem()
+	if err != nil {
+		errVal.Set(reflect.ValueOf(err))
+	}
+
+	return append(out, errVal)
+}
+
+func do(ctx context.Context, url string, m internal.RPCMethod, args []reflect.Value) (reflect.Value, error) {
+	var result reflect.Value
+	if m.Result != nil {
+		result = reflect.New(m.Result).Elem()
+	}
+
+	body := internal.RPCRequest{
+		Args: make([]json.RawMessage, len(args)),
+	}
+
+	for i := range args {
+		b, err := json.Marshal(args[i].Interface())
+		if err != nil {
+			return result, &Error{Method: m.Name, Message: err.Error()}
+		}
+		body.Args[i] = b
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return result, &Error{Method: m.Name, Message: err.Error()}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return result, &Error{Method: m.Name, Message: err.Error()}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := oasisHttp.Do(req)
+	if err != nil {
+		return result, &Error{Method: m.Name, Message: err.Error()}
+	}
+	if res == nil || res.Body == nil {
+		return result, &Error{Method: m.Name, Message: "no response"}
+	}
+	defer res.Body.Close()
+
+	b, err = io.ReadAll(res.Body)
+	if err != nil {
+		return result, &Error{Method: m.Name, StatusCode: res.StatusCode, Message: err.Error()}
+	}
+
+	rpcRes := internal.RPCResponse{}
+
+	err = json.Unmarshal(b, &rpcRes)
+	if err != nil {
+		return result, &Error{Method: m.Name, StatusCode: res.StatusCode, Message: fmt.Sprintf("unexpected response: %s", res.Status)}
+	}
+
+	if rpcRes.Error != "" {
+		return result, &Error{Method: m.Name, StatusCode: res.StatusCode, Message: rpcRes.Error}
+	}
+
+	if m.Result != nil && len(rpcRes.Result) > 0 {
+		err = json.Unmarshal(rpcRes.Result, result.Addr().Interface())
+		if err != nil {
+			return reflect.New(m.Result).Elem(), &Error{Method: m.Name, StatusCode: res.StatusCode, Message: err.Error()}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,139 @@
+//go:build js && wasm
+
+package state
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/console"
+	"github.com/syke99/oasis/client/dom"
+	"sync"
+)
+
+// binding writes the value of a
+// Readable to an Element
+type binding struct {
+	o      *observer
+	update func()
+}
+
+func (b *binding) Remove() {
+	b.o.stop()
+}
+
+// Bind calls apply with the value of src right away,
+// then again on the next animation frame whenever it
+// changes, however many times it changed before then,
+// until the returned Subscription is removed. It is
+// for patching the DOM in ways the other Bind funcs
+// don't cover
+func Bind[T any](src Readable[T], apply func(val T)) dom.Subscription {
+	b := &binding{}
+
+	b.o = &observer{
+		changed: func() {
+			schedule(b)
+		},
+	}
+
+	b.update = func() {
+		if b.o.isStopped() {
+			return
+		}
+
+		var val T
+		b.o.track(func() {
+			val = src.Get()
+		})
+
+		apply(val)
+	}
+
+	b.update()
+
+	return b
+}
+
+var (
+	frameMu   sync.Mutex
+	pending   []*binding
+	scheduled map[*binding]bool
+)
+
+// schedule queues b to be updated on the
+// next animation frame, requesting one
+// if none has been yet
+func schedule(b *binding) {
+	frameMu.Lock()
+	defer frameMu.Unlock()
+
+	if scheduled == nil {
+		scheduled = make(map[*binding]bool)
+	}
+	if scheduled[b] {
+		return
+	}
+
+	scheduled[b] = true
+	pending = append(pending, b)
+
+	if len(pending) == 1 {
+		dom.Window.RequestAnimationFrame(func(float64) {
+			frameMu.Lock()
+			bindings := pending
+			pending = nil
+			scheduled = nil
+			frameMu.Unlock()
+
+			for _, b := range bindings {
+				b.update()
+			}
+		})
+	}
+}
+
+// BindText keeps the text content of el set to
+// the value of src, formatted with fmt.Sprint
+func BindText[T any](el dom.Element, src Readable[T]) dom.Subscription {
+	return Bind(src, func(val T) {
+		el.JSValue().Set("textContent", fmt.Sprint(val))
+	})
+}
+
+// BindAttribute keeps the attribute of el with the given
+// name set to the value of src, formatted with fmt.Sprint.
+// A bool value instead adds the attribute when it is
+// true and removes it when it is false, like for the
+// "disabled" or "hidden" attributes
+func BindAttribute[T any](el dom.Element, name string, src Readable[T]) dom.Subscription {
+	return Bind(src, func(val T) {
+		if b, ok := any(val).(bool); ok {
+			el.JSValue().Call("toggleAttribute", name, b)
+			return
+		}
+		el.JSValue().Call("setAttribute", name, fmt.Sprint(val))
+	})
+}
+
+// BindClass adds class to el's classes while
+// the value of src is true, and removes it
+// while it is false
+func BindClass(el dom.Element, class string, src Readable[bool]) dom.Subscription {
+	return Bind(src, func(val bool) {
+		el.JSValue().Get("classList").Call("toggle", class, val)
+	})
+}
+
+// BindProperty keeps the JavaScript property of el
+// with the given name, e.g. "value" or "checked", set
+// to the value of src. js.Values and Elements are set
+// as they are; anything else is converted through JSON
+func BindProperty[T any](el dom.Element, name string, src Readable[T]) dom.Subscription {
+	return Bind(src, func(val T) {
+		v, err := dom.ToJS(val)
+		if err != nil {
+			console.ErrMessage(fmt.Sprintf("could not bind property %s: %s", name, err.Error()), nil)
+			return
+		}
+		el.JSValue().Set(name, v)
+	})
+}
@@ -0,0 +1,185 @@
+package state
+
+import (
+	"sync"
+)
+
+// Computed is a reactive value derived from other
+// Signals and Computeds. It is computed lazily, the
+// first time it is read after its dependencies change
+type Computed[T any] struct {
+	fn    func() T
+	mu    sync.Mutex
+	val   T
+	dirty bool
+	o     *observer
+	listeners
+}
+
+// NewComputed returns a Computed whose value is
+// the result of fn, which is called again whenever
+// a value it read with Get has changed
+func NewComputed[T any](fn func() T) *Computed[T] {
+	c := &Computed[T]{
+		fn:    fn,
+		dirty: true,
+	}
+
+	c.o = &observer{
+		changed: c.invalidate,
+	}
+
+	return c
+}
+
+// Get returns the Computed's value and tracks
+// it as a dependency of the Computed, Effect
+// or binding being run, if any
+func (c *Computed[T]) Get() T {
+	depend(c)
+	return c.Peek()
+}
+
+// Peek returns the Computed's value
+// without tracking it
+func (c *Computed[T]) Peek() T {
+	c.mu.Lock()
+	if !c.dirty {
+		defer c.mu.Unlock()
+		return c.val
+	}
+	c.mu.Unlock()
+
+	var val T
+	c.o.track(func() {
+		val = c.fn()
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.val = val
+	c.dirty = false
+
+	return val
+}
+
+// invalidate marks the Computed to be computed again.
+// It is called as soon as a dependency changes, before
+// any Effect runs, so that Effects never see a
+// Computed that is out of date
+func (c *Computed[T]) invalidate() {
+	c.mu.Lock()
+	if c.dirty {
+		c.mu.Unlock()
+		return
+	}
+	c.dirty = true
+	c.mu.Unlock()
+
+	c.notify()
+}
+
+// Subscription stops an Effect or a binding from
+// being run again. It satisfies dom.Subscription
+type Subscription interface {
+	// Remove stops it from being run again.
+	// It is safe to call more than once
+	Remove()
+}
+
+// effect is a func that is run
+// again when its dependencies change
+type effect struct {
+	fn func()
+	o  *observer
+}
+
+func (e *effect) run() {
+	if e.o.isStopped() {
+		return
+	}
+	e.o.track(e.fn)
+}
+
+func (e *effect) Remove() {
+	e.o.stop()
+}
+
+// Effect calls fn, and calls it again whenever a
+// Signal or Computed it read with Get has changed,
+// until the returned Subscription is removed. fn
+// is called once for every Set or Batch, however
+// many of its dependencies were changed by it
+func Effect(fn func()) Subscription {
+	e := &effect{
+		fn: fn,
+	}
+
+	e.o = &observer{
+		changed: func() {
+			enqueue(e)
+		},
+	}
+
+	e.run()
+
+	return e
+}
+
+var (
+	batchMu sync.Mutex
+	depth   int
+	queue   []*effect
+	queued  map[*effect]bool
+)
+
+// Batch calls fn, and holds off on running the
+// Effects that depend on the Signals it sets until
+// it returns, so that each of them runs only once
+func Batch(fn func()) {
+	batchMu.Lock()
+	depth++
+	batchMu.Unlock()
+
+	defer flush()
+
+	fn()
+}
+
+func enqueue(e *effect) {
+	batchMu.Lock()
+	defer batchMu.Unlock()
+
+	if queued == nil {
+		queued = make(map[*effect]bool)
+	}
+	if queued[e] {
+		return
+	}
+
+	queued[e] = true
+	queue = append(queue, e)
+}
+
+// flush ends a Batch, running the Effects it
+// queued if it is the outermost one. Effects that
+// set Signals queue up further Effects, which are
+// run in turn until there are none left
+func flush() {
+	for {
+		batchMu.Lock()
+		if depth > 1 || len(queue) == 0 {
+			depth--
+			batchMu.Unlock()
+			return
+		}
+
+		e := queue[0]
+		queue = queue[1:]
+		delete(queued, e)
+		batchMu.Unlock()
+
+		e.run()
+	}
+}
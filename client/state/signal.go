@@ -0,0 +1,209 @@
+package state
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Readable is a reactive value: a Signal or a Computed.
+// Reading it with Get inside of a Computed, an Effect or
+// a binding makes it re-run whenever the value changes
+type Readable[T any] interface {
+	// Get returns the current value and tracks
+	// it as a dependency of the Computed, Effect
+	// or binding being run, if any
+	Get() T
+	// Peek returns the current value
+	// without tracking it
+	Peek() T
+	source
+}
+
+// source is anything observers can depend on
+type source interface {
+	// subscribe notifies o whenever the source
+	// changes, until the returned func is called
+	subscribe(o *observer) (unsubscribe func())
+}
+
+// listeners holds the observers subscribed to a source
+type listeners struct {
+	mu        sync.Mutex
+	observers map[*observer]int
+}
+
+func (l *listeners) subscribe(o *observer) func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.observers == nil {
+		l.observers = make(map[*observer]int)
+	}
+	l.observers[o]++
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.observers[o]--
+		if l.observers[o] <= 0 {
+			delete(l.observers, o)
+		}
+	}
+}
+
+func (l *listeners) notify() {
+	l.mu.Lock()
+	observers := make([]*observer, 0, len(l.observers))
+	for o := range l.observers {
+		observers = append(observers, o)
+	}
+	l.mu.Unlock()
+
+	for _, o := range observers {
+		o.notify()
+	}
+}
+
+// Signal is a reactive value that
+// can be set directly
+type Signal[T any] struct {
+	mu  sync.RWMutex
+	val T
+	listeners
+}
+
+// NewSignal returns a Signal holding initial
+func NewSignal[T any](initial T) *Signal[T] {
+	return &Signal[T]{
+		val: initial,
+	}
+}
+
+// Get returns the Signal's value and tracks
+// it as a dependency of the Computed, Effect
+// or binding being run, if any
+func (s *Signal[T]) Get() T {
+	depend(s)
+	return s.Peek()
+}
+
+// Peek returns the Signal's value
+// without tracking it
+func (s *Signal[T]) Peek() T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.val
+}
+
+// Set sets the Signal's value, and re-runs everything
+// that depends on it, unless val is deeply equal
+// to the Signal's current value
+func (s *Signal[T]) Set(val T) {
+	s.mu.Lock()
+	if reflect.DeepEqual(s.val, val) {
+		s.mu.Unlock()
+		return
+	}
+	s.val = val
+	s.mu.Unlock()
+
+	Batch(s.notify)
+}
+
+// Update sets the Signal's value to the
+// result of calling fn with its current one
+func (s *Signal[T]) Update(fn func(T) T) {
+	s.Set(fn(s.Peek()))
+}
+
+// observer is the Computed, Effect or binding
+// that dependencies are being tracked for
+type observer struct {
+	mu   sync.Mutex
+	deps map[source]func()
+	// changed is called as soon as a dependency
+	// changes, so it must only invalidate or
+	// queue up the observer, not run it
+	changed func()
+	stopped bool
+}
+
+var (
+	trackMu  sync.Mutex
+	tracking *observer
+)
+
+// track calls fn with o as the observer being run,
+// so that the sources fn reads with Get become o's
+// dependencies in place of the ones it had before
+func (o *observer) track(fn func()) {
+	o.mu.Lock()
+	for _, unsubscribe := range o.deps {
+		unsubscribe()
+	}
+	o.deps = make(map[source]func())
+	o.mu.Unlock()
+
+	trackMu.Lock()
+	prev := tracking
+	tracking = o
+	trackMu.Unlock()
+
+	defer func() {
+		trackMu.Lock()
+		tracking = prev
+		trackMu.Unlock()
+	}()
+
+	fn()
+}
+
+func (o *observer) stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.stopped = true
+	for _, unsubscribe := range o.deps {
+		unsubscribe()
+	}
+	o.deps = nil
+}
+
+func (o *observer) isStopped() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.stopped
+}
+
+func (o *observer) notify() {
+	if !o.isStopped() {
+		o.changed()
+	}
+}
+
+// depend makes s a dependency of
+// the observer being run, if any
+func depend(s source) {
+	trackMu.Lock()
+	o := tracking
+	trackMu.Unlock()
+
+	if o == nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.stopped || o.deps == nil {
+		return
+	}
+	if _, ok := o.deps[s]; ok {
+		return
+	}
+
+	o.deps[s] = s.subscribe(o)
+}
@@ -0,0 +1,127 @@
+package state
+
+import (
+	"testing"
+)
+
+func TestNestedBatch(t *testing.T) {
+	a := NewSignal(0)
+	b := NewSignal(0)
+
+	runs := 0
+	sub := Effect(func() {
+		a.Get()
+		b.Get()
+		runs++
+	})
+	defer sub.Remove()
+
+	Batch(func() {
+		a.Set(1)
+
+		Batch(func() {
+			b.Set(1)
+		})
+
+		if runs != 1 {
+			t.Errorf("expected the Effect not to run until the outer Batch ends, ran %d times", runs)
+		}
+
+		a.Set(2)
+	})
+
+	if runs != 2 {
+		t.Errorf("expected the Effect to run once for the Batch, ran %d times", runs-1)
+	}
+}
+
+func TestDiamond(t *testing.T) {
+	a := NewSignal(1)
+	double := NewComputed(func() int {
+		return a.Get() * 2
+	})
+	inc := NewComputed(func() int {
+		return a.Get() + 1
+	})
+	sum := NewComputed(func() int {
+		return double.Get() + inc.Get()
+	})
+
+	var seen []int
+	sub := Effect(func() {
+		seen = append(seen, sum.Get())
+	})
+	defer sub.Remove()
+
+	a.Set(2)
+	a.Set(3)
+
+	want := []int{4, 7, 10}
+	if len(seen) != len(want) {
+		t.Fatalf("expected the Effect to run once per Set, got %v", seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, seen)
+			break
+		}
+	}
+}
+
+func TestComputedInvalidation(t *testing.T) {
+	a := NewSignal(1)
+
+	computes := 0
+	c := NewComputed(func() int {
+		computes++
+		return a.Get() * 10
+	})
+
+	if v := c.Peek(); v != 10 {
+		t.Errorf("expected 10, got %d", v)
+	}
+	c.Peek()
+	if computes != 1 {
+		t.Errorf("expected the value to be reused, computed %d times", computes)
+	}
+
+	a.Set(2)
+	if computes != 1 {
+		t.Errorf("expected the Computed to be computed lazily, computed %d times", computes)
+	}
+
+	if v := c.Peek(); v != 20 {
+		t.Errorf("expected 20 after invalidation, got %d", v)
+	}
+	if computes != 2 {
+		t.Errorf("expected the Computed to be computed again once, computed %d times", computes)
+	}
+
+	a.Set(2)
+	c.Peek()
+	if computes != 2 {
+		t.Errorf("expected an equal value not to invalidate the Computed, computed %d times", computes)
+	}
+}
+
+func TestRemoveEffect(t *testing.T) {
+	a := NewSignal(0)
+
+	runs := 0
+	sub := Effect(func() {
+		a.Get()
+		runs++
+	})
+
+	a.Set(1)
+	sub.Remove()
+	sub.Remove()
+	a.Set(2)
+
+	if runs != 2 {
+		t.Errorf("expected the Effect not to run once removed, ran %d times", runs)
+	}
+	if len(a.observers) != 0 {
+		t.Errorf("expected the Effect to unsubscribe, %d observers left", len(a.observers))
+	}
+}
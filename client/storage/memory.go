@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"sort"
+	"sync"
+)
+
+// memory is a Backend that
+// keeps its values in a map
+type memory struct {
+	mu    sync.RWMutex
+	items map[string]string
+}
+
+func (m *memory) GetItem(key string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	val, ok := m.items[key]
+
+	return val, ok
+}
+
+func (m *memory) SetItem(key string, val string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items[key] = val
+
+	return nil
+}
+
+func (m *memory) RemoveItem(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.items, key)
+}
+
+func (m *memory) Keys() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, 0, len(m.items))
+	for k := range m.items {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (m *memory) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.items = make(map[string]string)
+}
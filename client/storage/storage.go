@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrQuotaExceeded is returned by Set when
+	// the browser has no room left to store the
+	// value in the Store
+	ErrQuotaExceeded = errors.New("storage: quota exceeded")
+	// ErrUnavailable is returned by Set when the
+	// browser doesn't provide the Store's storage,
+	// e.g. because it has been disabled
+	ErrUnavailable = errors.New("storage: unavailable")
+)
+
+// Backend holds the raw, string values of a Store
+type Backend interface {
+	// GetItem returns the value stored at key,
+	// and whether there is one
+	GetItem(key string) (string, bool)
+	// SetItem stores val at key
+	SetItem(key string, val string) error
+	// RemoveItem removes the value stored at key
+	RemoveItem(key string)
+	// Keys returns every key with a value
+	// stored at it, sorted
+	Keys() []string
+	// Clear removes every value
+	Clear()
+}
+
+// Change describes a change made to a Store
+type Change struct {
+	// Key is the key whose value changed,
+	// or empty if the Store was cleared
+	Key string
+	// OldValue and NewValue are the JSON the value
+	// was and is encoded as, or empty if there
+	// wasn't or isn't one; see Decode
+	OldValue string
+	NewValue string
+	// Remote is true when the change was made
+	// from another tab or window of the page
+	Remote bool
+}
+
+// Subscription stops a function passed to OnChange
+// from being called. It satisfies dom.Subscription
+type Subscription interface {
+	// Remove stops the function from being
+	// called. It is safe to call more than once
+	Remove()
+}
+
+// Store holds JSON-encoded values
+// under string keys in a Backend
+type Store struct {
+	backend Backend
+	// remote, if set, calls fn with the changes
+	// made to the Store from other windows of
+	// the page, until the Subscription is removed
+	remote func(fn func(Change)) Subscription
+	mu     sync.Mutex
+	subs   map[int]func(Change)
+	next   int
+}
+
+// New returns a Store that keeps
+// its values in backend
+func New(backend Backend) *Store {
+	return &Store{
+		backend: backend,
+	}
+}
+
+// NewMemory returns a Store that keeps its values
+// in memory, e.g. for tests, or as a fallback when
+// the browser's storage is unavailable
+func NewMemory() *Store {
+	return New(&memory{
+		items: make(map[string]string),
+	})
+}
+
+// Get decodes the value stored at key in s into a
+// T, and reports whether there was one. Absent values
+// are returned as the zero value of T with no error
+func Get[T any](s *Store, key string) (T, bool, error) {
+	var val T
+
+	raw, ok := s.backend.GetItem(key)
+	if !ok {
+		return val, false, nil
+	}
+
+	val, err := Decode[T](raw)
+
+	return val, true, err
+}
+
+// Decode decodes a raw value, such as
+// the OldValue or NewValue of a Change
+func Decode[T any](raw string) (T, error) {
+	var val T
+
+	if raw == "" {
+		return val, nil
+	}
+
+	err := json.Unmarshal([]byte(raw), &val)
+	if err != nil {
+		return val, fmt.Errorf("storage: %w", err)
+	}
+
+	return val, nil
+}
+
+// Has reports whether a value is stored at key
+func (s *Store) Has(key string) bool {
+	_, ok := s.backend.GetItem(key)
+	return ok
+}
+
+// Set stores val, encoded as JSON, at key.
+// It returns ErrQuotaExceeded if there isn't
+// enough room left to store it
+func (s *Store) Set(key string, val any) error {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Errorf("storage: %w", err)
+	}
+
+	old, _ := s.backend.GetItem(key)
+
+	err = s.backend.SetItem(key, string(b))
+	if err != nil {
+		return err
+	}
+
+	s.notify(Change{
+		Key:      key,
+		OldValue: old,
+		NewValue: string(b),
+	})
+
+	return nil
+}
+
+// Delete removes the value stored at key
+func (s *Store) Delete(key string) {
+	old, ok := s.backend.GetItem(key)
+	if !ok {
+		return
+	}
+
+	s.backend.RemoveItem(key)
+
+	s.notify(Change{
+		Key:      key,
+		OldValue: old,
+	})
+}
+
+// Keys returns every key with a
+// value stored at it, sorted
+func (s *Store) Keys() []string {
+	return s.backend.Keys()
+}
+
+// Clear removes every value from s
+func (s *Store) Clear() {
+	s.backend.Clear()
+	s.notify(Change{})
+}
+
+// OnChange calls fn with every change made to s, both
+// through s itself and, for Local and Session, from other
+// windows of the page through the window's OnStorage
+// event. The returned Subscription stops it
+func (s *Store) OnChange(fn func(Change)) Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subs == nil {
+		s.subs = make(map[int]func(Change))
+	}
+
+	id := s.next
+	s.next++
+	s.subs[id] = fn
+
+	sub := &subscription{
+		store: s,
+		id:    id,
+	}
+
+	if s.remote != nil {
+		sub.remote = s.remote(fn)
+	}
+
+	return sub
+}
+
+func (s *Store) notify(change Change) {
+	s.mu.Lock()
+	fns := make([]func(Change), 0, len(s.subs))
+	for _, fn := range s.subs {
+		fns = append(fns, fn)
+	}
+	s.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(change)
+	}
+}
+
+type subscription struct {
+	store  *Store
+	id     int
+	remote Subscription
+}
+
+func (s *subscription) Remove() {
+	s.store.mu.Lock()
+	delete(s.store.subs, s.id)
+	s.store.mu.Unlock()
+
+	if s.remote != nil {
+		s.remote.Remove()
+	}
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+type prefs struct {
+	Theme string
+	Size  int
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemory()
+
+	if _, ok, err := Get[prefs](s, "prefs"); ok || err != nil {
+		t.Fatalf("expected no value, got %v %v", ok, err)
+	}
+
+	if err := s.Set("prefs", prefs{Theme: "dark", Size: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("count", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	p, ok, err := Get[prefs](s, "prefs")
+	if err != nil || !ok {
+		t.Fatalf("expected a value, got %v %v", ok, err)
+	}
+	if p != (prefs{Theme: "dark", Size: 2}) {
+		t.Errorf("expected the stored value, got %+v", p)
+	}
+
+	if keys := s.Keys(); !reflect.DeepEqual(keys, []string{"count", "prefs"}) {
+		t.Errorf("expected sorted keys, got %v", keys)
+	}
+
+	if _, _, err = Get[prefs](s, "count"); err == nil {
+		t.Error("expected an error decoding into the wrong type")
+	}
+
+	s.Delete("count")
+	if s.Has("count") {
+		t.Error("expected count to be deleted")
+	}
+
+	s.Clear()
+	if len(s.Keys()) != 0 {
+		t.Errorf("expected an empty store, got %v", s.Keys())
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	s := NewMemory()
+
+	var changes []Change
+	sub := s.OnChange(func(c Change) {
+		changes = append(changes, c)
+	})
+
+	s.Set("name", "oasis")
+	s.Set("name", "island")
+	s.Delete("name")
+	s.Delete("missing")
+	s.Clear()
+
+	want := []Change{
+		{Key: "name", NewValue: `"oasis"`},
+		{Key: "name", OldValue: `"oasis"`, NewValue: `"island"`},
+		{Key: "name", OldValue: `"island"`},
+		{},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("expected %+v, got %+v", want, changes)
+	}
+
+	sub.Remove()
+	sub.Remove()
+
+	s.Set("name", "oasis")
+	if len(changes) != len(want) {
+		t.Errorf("expected no changes after Remove, got %+v", changes[len(want):])
+	}
+
+	old, err := Decode[string](want[1].OldValue)
+	if err != nil || old != "oasis" {
+		t.Errorf("expected the old value to decode, got %q %v", old, err)
+	}
+}
@@ -0,0 +1,145 @@
+//go:build js && wasm
+
+package storage
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/dom"
+	"sort"
+	"syscall/js"
+)
+
+var (
+	// Local is the Store backed by the browser's
+	// localStorage, which persists across sessions
+	Local = newWeb("localStorage")
+	// Session is the Store backed by the browser's
+	// sessionStorage, which is cleared once the
+	// page's session ends
+	Session = newWeb("sessionStorage")
+)
+
+func newWeb(name string) *Store {
+	w := &web{
+		name: name,
+	}
+
+	s := New(w)
+	s.remote = w.watch
+
+	return s
+}
+
+// web is a Backend for one of
+// the browser's Storage objects
+type web struct {
+	name string
+}
+
+// storage returns the Storage, which is looked
+// up on every call since accessing it can fail
+// until the page allows it
+func (w *web) storage() (area js.Value) {
+	defer func() {
+		if recover() != nil {
+			area = js.Undefined()
+		}
+	}()
+
+	return js.Global().Get(w.name)
+}
+
+func (w *web) GetItem(key string) (string, bool) {
+	area := w.storage()
+	if !area.Truthy() {
+		return "", false
+	}
+
+	val := area.Call("getItem", key)
+	if val.IsNull() {
+		return "", false
+	}
+
+	return val.String(), true
+}
+
+func (w *web) SetItem(key string, val string) (err error) {
+	area := w.storage()
+	if !area.Truthy() {
+		return ErrUnavailable
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, ok := r.(js.Error)
+			if ok && jsErr.Get("name").String() == "QuotaExceededError" {
+				err = fmt.Errorf("%w: could not store %s", ErrQuotaExceeded, key)
+				return
+			}
+			err = fmt.Errorf("storage: could not store %s: %v", key, r)
+		}
+	}()
+
+	area.Call("setItem", key, val)
+
+	return nil
+}
+
+func (w *web) RemoveItem(key string) {
+	if area := w.storage(); area.Truthy() {
+		area.Call("removeItem", key)
+	}
+}
+
+func (w *web) Keys() []string {
+	area := w.storage()
+	if !area.Truthy() {
+		return []string{}
+	}
+
+	l := area.Length()
+
+	keys := make([]string, l)
+
+	for i := 0; i < l; i++ {
+		keys[i] = area.Call("key", i).String()
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (w *web) Clear() {
+	if area := w.storage(); area.Truthy() {
+		area.Call("clear")
+	}
+}
+
+// watch calls fn with the changes made to the Storage
+// from other windows of the page, telling them apart
+// from those made to the page's other Storage
+func (w *web) watch(fn func(Change)) Subscription {
+	return dom.Window.On(dom.OnStorage, func(args ...js.Value) interface{} {
+		e := args[0]
+		if !e.Get("storageArea").Equal(w.storage()) {
+			return nil
+		}
+
+		fn(Change{
+			Key:      stringOrEmpty(e.Get("key")),
+			OldValue: stringOrEmpty(e.Get("oldValue")),
+			NewValue: stringOrEmpty(e.Get("newValue")),
+			Remote:   true,
+		})
+
+		return nil
+	})
+}
+
+func stringOrEmpty(val js.Value) string {
+	if val.IsNull() || val.IsUndefined() {
+		return ""
+	}
+	return val.String()
+}
@@ -0,0 +1,49 @@
+package vdom
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestPatchChildrenUnderNode runs the js/wasm tests
+// of this package in node, with testdata/dom.js
+// standing in for the browser's DOM
+func TestPatchChildrenUnderNode(t *testing.T) {
+	if runtime.GOOS == "js" {
+		t.Skip("runs js/wasm from the host")
+	}
+	if testing.Short() {
+		t.Skip("builds and runs the package under js/wasm")
+	}
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is needed to run js/wasm")
+	}
+
+	execPath := filepath.Join(runtime.GOROOT(), "lib", "wasm", "go_js_wasm_exec")
+	if _, err := os.Stat(execPath); err != nil {
+		execPath = filepath.Join(runtime.GOROOT(), "misc", "wasm", "go_js_wasm_exec")
+	}
+	if _, err := os.Stat(execPath); err != nil {
+		t.Skip("go_js_wasm_exec not found")
+	}
+
+	fakeDOM, err := filepath.Abs(filepath.Join("testdata", "dom.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "test", "-count=1", "-v", "-run", "^TestPatchChildrenKeyed$", "-exec", execPath, ".")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm", "NODE_OPTIONS=--require="+fakeDOM)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running under js/wasm: %v\n%s", err, out)
+	}
+	if !bytes.Contains(out, []byte("--- PASS: TestPatchChildrenKeyed")) {
+		t.Fatalf("TestPatchChildrenKeyed didn't run under js/wasm:\n%s", out)
+	}
+}
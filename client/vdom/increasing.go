@@ -0,0 +1,46 @@
+package vdom
+
+import (
+	"sort"
+)
+
+// increasing returns which of idx are in the longest
+// increasing subsequence of the ones that aren't -1
+func increasing(idx []int) []bool {
+	// tails[k] is the position in idx of the smallest
+	// last value of an increasing subsequence of length
+	// k+1, and prev links each position to the one
+	// before it in its subsequence
+	var tails []int
+	prev := make([]int, len(idx))
+
+	for i, v := range idx {
+		prev[i] = -1
+		if v < 0 {
+			continue
+		}
+
+		k := sort.Search(len(tails), func(k int) bool {
+			return idx[tails[k]] >= v
+		})
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	stay := make([]bool, len(idx))
+	if len(tails) == 0 {
+		return stay
+	}
+
+	for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+		stay[i] = true
+	}
+
+	return stay
+}
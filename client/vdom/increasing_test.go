@@ -0,0 +1,123 @@
+package vdom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIncreasing(t *testing.T) {
+	tests := []struct {
+		name string
+		idx  []int
+		want []bool
+	}{
+		{
+			name: "empty",
+			idx:  []int{},
+			want: []bool{},
+		},
+		{
+			name: "in order",
+			idx:  []int{0, 1, 2},
+			want: []bool{true, true, true},
+		},
+		{
+			name: "last moved to the front",
+			idx:  []int{3, 0, 1, 2},
+			want: []bool{false, true, true, true},
+		},
+		{
+			name: "first moved to the back",
+			idx:  []int{1, 2, 3, 0},
+			want: []bool{true, true, true, false},
+		},
+		{
+			name: "new",
+			idx:  []int{-1, -1},
+			want: []bool{false, false},
+		},
+		{
+			name: "new between",
+			idx:  []int{0, -1, 2, -1, 3},
+			want: []bool{true, false, true, false, true},
+		},
+		{
+			name: "swapped",
+			idx:  []int{0, 2, 1, 3},
+		},
+		{
+			name: "reversed",
+			idx:  []int{3, 2, 1, 0},
+		},
+		{
+			name: "mixed",
+			idx:  []int{4, -1, 1, 5, 2, -1, 3, 0},
+			want: []bool{false, false, true, false, true, false, true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stay := increasing(tt.idx)
+
+			if tt.want != nil && !reflect.DeepEqual(stay, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, stay)
+			}
+
+			if len(stay) != len(tt.idx) {
+				t.Fatalf("expected %d results, got %d", len(tt.idx), len(stay))
+			}
+
+			last := -1
+			for i, ok := range stay {
+				if !ok {
+					continue
+				}
+				if tt.idx[i] <= last {
+					t.Errorf("expected the children that stay to be in order, got %v for %v", stay, tt.idx)
+				}
+				last = tt.idx[i]
+			}
+
+			if got, want := count(stay), lisLength(tt.idx); got != want {
+				t.Errorf("expected %d children to stay, got %d", want, got)
+			}
+		})
+	}
+}
+
+func count(stay []bool) int {
+	n := 0
+	for _, ok := range stay {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// lisLength is the length of the longest increasing
+// subsequence of idx, leaving out -1, found the slow way
+func lisLength(idx []int) int {
+	best := make([]int, len(idx))
+	longest := 0
+
+	for i, v := range idx {
+		if v < 0 {
+			continue
+		}
+
+		best[i] = 1
+		for j := 0; j < i; j++ {
+			if idx[j] >= 0 && idx[j] < v && best[j]+1 > best[i] {
+				best[i] = best[j] + 1
+			}
+		}
+
+		if best[i] > longest {
+			longest = best[i]
+		}
+	}
+
+	return longest
+}
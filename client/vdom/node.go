@@ -0,0 +1,87 @@
+//go:build js && wasm
+
+package vdom
+
+import (
+	"github.com/syke99/oasis/client/dom"
+)
+
+// Attrs are the HTML attributes of a Node
+type Attrs map[string]string
+
+// Node is a virtual DOM node: a lightweight
+// description of an element or a text node
+// that Render makes the live DOM match
+type Node struct {
+	tag      string
+	text     string
+	key      string
+	attrs    Attrs
+	props    map[string]any
+	events   map[dom.OnEvent]func(dom.Event)
+	children []*Node
+}
+
+// H returns a Node for an element with the given tag,
+// attributes, which may be nil, and children. nil
+// children are skipped, so that they can be
+// left out conditionally
+func H(tag string, attrs Attrs, children ...*Node) *Node {
+	n := &Node{
+		tag:   tag,
+		attrs: attrs,
+	}
+
+	for _, child := range children {
+		if child != nil {
+			n.children = append(n.children, child)
+		}
+	}
+
+	return n
+}
+
+// Text returns a Node for a text node
+func Text(text string) *Node {
+	return &Node{
+		text: text,
+	}
+}
+
+// Key sets the key of an element Node and returns it.
+// Render matches keyed Nodes to the elements rendered for
+// the Node with the same key among their siblings last
+// time, wherever they moved, so that those elements keep
+// their focus, scroll position and state; unkeyed Nodes
+// are matched to elements by their position
+func (n *Node) Key(key string) *Node {
+	n.key = key
+	return n
+}
+
+// Prop sets a JavaScript property of an element Node,
+// such as "value" or "checked", and returns it. Unlike
+// attributes, properties are only set when they differ
+// from the element's, and are left as they are when
+// a later render leaves them out. js.Values and Elements
+// are set as they are; anything else is converted
+// through JSON
+func (n *Node) Prop(name string, val any) *Node {
+	if n.props == nil {
+		n.props = make(map[string]any)
+	}
+	n.props[name] = val
+	return n
+}
+
+// On sets the func that handles the given event on an
+// element Node and returns it. Handlers are swapped in
+// place on every render, rather than being added and
+// removed again
+func (n *Node) On(name dom.OnEvent, fn func(e dom.Event)) *Node {
+	if n.events == nil {
+		n.events = make(map[dom.OnEvent]func(dom.Event))
+	}
+	n.events[name] = fn
+	return n
+}
@@ -0,0 +1,336 @@
+//go:build js && wasm
+
+package vdom
+
+import (
+	"fmt"
+	"github.com/syke99/oasis/client/console"
+	"github.com/syke99/oasis/client/dom"
+	"github.com/syke99/oasis/client/state"
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+const (
+	svgNS = "http://www.w3.org/2000/svg"
+	// idProp is the property that holds the ids of
+	// the elements Render keeps track of
+	idProp = "__oasisVDOM"
+)
+
+// Render patches the children of root to match nodes,
+// diffing them against root's live subtree, so that only
+// the elements, attributes, properties, text and event
+// handlers that differ are changed. Elements are reused
+// wherever they match, so they keep their focus, scroll
+// position and listeners. Render owns root's children:
+// anything else added to them is removed or overwritten
+// by the next render
+func Render(root dom.Element, nodes ...*Node) {
+	parent := root.JSValue()
+
+	var children []*Node
+	for _, n := range nodes {
+		if n != nil {
+			children = append(children, n)
+		}
+	}
+
+	patchChildren(parent, children, parent.Get("namespaceURI").String() == svgNS)
+}
+
+// Mount renders the Node view returns into root, then
+// renders it again on the next animation frame whenever
+// a state.Signal or state.Computed it read with Get
+// changes, until the returned Subscription is removed
+func Mount(root dom.Element, view func() *Node) dom.Subscription {
+	return state.Bind(state.NewComputed(view), func(n *Node) {
+		Render(root, n)
+	})
+}
+
+// tracked holds what Render knows about
+// an element that isn't in the DOM itself
+type tracked struct {
+	key    string
+	events map[dom.OnEvent]*handler
+}
+
+type handler struct {
+	fn  func(dom.Event)
+	sub dom.Subscription
+}
+
+var (
+	mu       sync.Mutex
+	elements = make(map[int]*tracked)
+	nextID   int
+)
+
+// trackedBy returns what is tracked for el, or
+// nil if nothing is, unless create is true
+func trackedBy(el js.Value, create bool) *tracked {
+	mu.Lock()
+	defer mu.Unlock()
+
+	id := el.Get(idProp)
+	if !id.IsUndefined() {
+		return elements[id.Int()]
+	}
+	if !create {
+		return nil
+	}
+
+	t := &tracked{
+		events: make(map[dom.OnEvent]*handler),
+	}
+
+	nextID++
+	elements[nextID] = t
+	el.Set(idProp, nextID)
+
+	return t
+}
+
+// release removes the listeners of node and
+// its descendants and stops tracking them
+func release(node js.Value) {
+	if node.Get("nodeType").Int() != 1 {
+		return
+	}
+
+	if t := trackedBy(node, false); t != nil {
+		for _, h := range t.events {
+			h.sub.Remove()
+		}
+
+		mu.Lock()
+		delete(elements, node.Get(idProp).Int())
+		mu.Unlock()
+
+		node.Delete(idProp)
+	}
+
+	children := node.Get("children")
+	for i := 0; i < children.Length(); i++ {
+		release(children.Index(i))
+	}
+}
+
+func keyOf(node js.Value) string {
+	if node.Get("nodeType").Int() != 1 {
+		return ""
+	}
+	if t := trackedBy(node, false); t != nil {
+		return t.key
+	}
+	return ""
+}
+
+// matches reports whether node can be patched to
+// match n rather than having to be replaced
+func matches(node js.Value, n *Node) bool {
+	if n.tag == "" {
+		return node.Get("nodeType").Int() == 3
+	}
+
+	return node.Get("nodeType").Int() == 1 &&
+		strings.EqualFold(node.Get("nodeName").String(), n.tag)
+}
+
+// patchChildren makes the child nodes of parent match
+// nodes. Keyed Nodes are matched to the child with the
+// same key, and unkeyed ones to the unkeyed children in
+// order; children that match no Node are removed, Nodes
+// that match no child are created, and the fewest
+// children possible are moved into place
+func patchChildren(parent js.Value, nodes []*Node, svg bool) {
+	childNodes := parent.Get("childNodes")
+
+	live := make([]js.Value, childNodes.Length())
+	keyed := make(map[string]int)
+	var unkeyed []int
+
+	for i := range live {
+		live[i] = childNodes.Index(i)
+
+		if key := keyOf(live[i]); key != "" {
+			keyed[key] = i
+			continue
+		}
+		unkeyed = append(unkeyed, i)
+	}
+
+	used := make([]bool, len(live))
+	matched := make([]int, len(nodes))
+
+	for i, n := range nodes {
+		matched[i] = -1
+
+		if n.key != "" {
+			j, ok := keyed[n.key]
+			if ok && !used[j] && matches(live[j], n) {
+				matched[i] = j
+				used[j] = true
+			}
+			continue
+		}
+
+		if len(unkeyed) == 0 {
+			continue
+		}
+
+		j := unkeyed[0]
+		unkeyed = unkeyed[1:]
+
+		if matches(live[j], n) {
+			matched[i] = j
+			used[j] = true
+		}
+	}
+
+	for j, node := range live {
+		if !used[j] {
+			release(node)
+			parent.Call("removeChild", node)
+		}
+	}
+
+	rendered := make([]js.Value, len(nodes))
+	for i, n := range nodes {
+		if matched[i] >= 0 {
+			rendered[i] = live[matched[i]]
+			patch(rendered[i], n, svg)
+			continue
+		}
+		rendered[i] = create(n, svg)
+	}
+
+	// the children that are already in order stay
+	// put, and the rest are moved around them,
+	// back to front, so that as few as possible
+	// are moved
+	stay := increasing(matched)
+
+	ref := js.Null()
+	for i := len(rendered) - 1; i >= 0; i-- {
+		if !stay[i] {
+			parent.Call("insertBefore", rendered[i], ref)
+		}
+		ref = rendered[i]
+	}
+}
+
+func create(n *Node, svg bool) js.Value {
+	doc := dom.Document.JSValue()
+
+	if n.tag == "" {
+		return doc.Call("createTextNode", n.text)
+	}
+
+	var el js.Value
+	if svg || n.tag == "svg" {
+		el = doc.Call("createElementNS", svgNS, n.tag)
+	} else {
+		el = doc.Call("createElement", n.tag)
+	}
+
+	patch(el, n, svg)
+
+	return el
+}
+
+// patch makes node, which matches n, match it exactly
+func patch(node js.Value, n *Node, svg bool) {
+	if n.tag == "" {
+		if node.Get("data").String() != n.text {
+			node.Set("data", n.text)
+		}
+		return
+	}
+
+	patchAttrs(node, n.attrs)
+	patchProps(node, n.props)
+	patchEvents(node, n)
+
+	if n.tag == "svg" {
+		svg = true
+	}
+	if n.tag == "foreignObject" {
+		svg = false
+	}
+
+	patchChildren(node, n.children, svg)
+}
+
+func patchAttrs(el js.Value, attrs Attrs) {
+	live := el.Get("attributes")
+
+	var stale []string
+	for i := 0; i < live.Length(); i++ {
+		name := live.Index(i).Get("name").String()
+		if _, ok := attrs[name]; !ok {
+			stale = append(stale, name)
+		}
+	}
+
+	for _, name := range stale {
+		el.Call("removeAttribute", name)
+	}
+
+	for name, val := range attrs {
+		cur := el.Call("getAttribute", name)
+		if cur.IsNull() || cur.String() != val {
+			el.Call("setAttribute", name, val)
+		}
+	}
+}
+
+func patchProps(el js.Value, props map[string]any) {
+	for name, val := range props {
+		v, err := dom.ToJS(val)
+		if err != nil {
+			console.ErrMessage(fmt.Sprintf("could not render property %s: %s", name, err.Error()), nil)
+			continue
+		}
+
+		if !el.Get(name).Equal(v) {
+			el.Set(name, v)
+		}
+	}
+}
+
+func patchEvents(el js.Value, n *Node) {
+	t := trackedBy(el, n.key != "" || len(n.events) > 0)
+	if t == nil {
+		return
+	}
+
+	t.key = n.key
+
+	for name, h := range t.events {
+		if _, ok := n.events[name]; !ok {
+			h.sub.Remove()
+			delete(t.events, name)
+		}
+	}
+
+	for name, fn := range n.events {
+		if h, ok := t.events[name]; ok {
+			h.fn = fn
+			continue
+		}
+
+		h := &handler{
+			fn: fn,
+		}
+
+		h.sub = dom.Wrap(el).On(name, func(args ...js.Value) interface{} {
+			h.fn(dom.WrapEvent(args[0]))
+			return nil
+		})
+
+		t.events[name] = h
+	}
+}
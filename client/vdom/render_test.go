@@ -0,0 +1,123 @@
+//go:build js && wasm
+
+package vdom
+
+import (
+	"syscall/js"
+	"testing"
+)
+
+func keyedList(keys []string) []*Node {
+	nodes := make([]*Node, len(keys))
+	for i, key := range keys {
+		nodes[i] = H("li", Attrs{"id": key}, Text(key)).Key(key)
+	}
+	return nodes
+}
+
+func childrenByID(parent js.Value) ([]string, map[string]js.Value) {
+	childNodes := parent.Get("childNodes")
+
+	ids := make([]string, childNodes.Length())
+	byID := make(map[string]js.Value, len(ids))
+
+	for i := range ids {
+		child := childNodes.Index(i)
+		ids[i] = child.Call("getAttribute", "id").String()
+		byID[ids[i]] = child
+	}
+
+	return ids, byID
+}
+
+func TestPatchChildrenKeyed(t *testing.T) {
+	doc := js.Global().Get("document")
+	if !doc.Truthy() {
+		t.Skip("needs the DOM in testdata/dom.js; run by TestPatchChildrenUnderNode")
+	}
+
+	tests := []struct {
+		name  string
+		from  []string
+		to    []string
+		moves int
+	}{
+		{
+			name: "unchanged",
+			from: []string{"a", "b", "c"},
+			to:   []string{"a", "b", "c"},
+		},
+		{
+			name:  "last moved to the front",
+			from:  []string{"a", "b", "c", "d"},
+			to:    []string{"d", "a", "b", "c"},
+			moves: 1,
+		},
+		{
+			name:  "swapped",
+			from:  []string{"a", "b", "c", "d"},
+			to:    []string{"a", "c", "b", "d"},
+			moves: 1,
+		},
+		{
+			name:  "reversed",
+			from:  []string{"a", "b", "c", "d"},
+			to:    []string{"d", "c", "b", "a"},
+			moves: 3,
+		},
+		{
+			name: "inserted",
+			from: []string{"a", "c"},
+			to:   []string{"x", "a", "b", "c", "y"},
+		},
+		{
+			name: "removed",
+			from: []string{"a", "b", "c", "d"},
+			to:   []string{"b", "d"},
+		},
+		{
+			name:  "inserted, removed and moved",
+			from:  []string{"a", "b", "c", "d", "e"},
+			to:    []string{"e", "x", "b", "d", "y"},
+			moves: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := doc.Call("createElement", "ul")
+
+			patchChildren(root, keyedList(tt.from), false)
+			_, before := childrenByID(root)
+
+			js.Global().Set("moves", 0)
+
+			patchChildren(root, keyedList(tt.to), false)
+			ids, after := childrenByID(root)
+
+			if len(ids) != len(tt.to) {
+				t.Fatalf("expected children %v, got %v", tt.to, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.to[i] {
+					t.Fatalf("expected children %v, got %v", tt.to, ids)
+				}
+			}
+
+			for id, el := range after {
+				if prev, ok := before[id]; ok && !prev.Equal(el) {
+					t.Errorf("expected the element keyed %s to be reused", id)
+				}
+			}
+			for id, el := range before {
+				if _, ok := after[id]; !ok && !el.Get("parentNode").IsNull() {
+					t.Errorf("expected the element keyed %s to be removed", id)
+				}
+			}
+
+			if moves := js.Global().Get("moves").Int(); moves != tt.moves {
+				t.Errorf("expected %d moves, got %d", tt.moves, moves)
+			}
+		})
+	}
+}
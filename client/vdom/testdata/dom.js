@@ -0,0 +1,79 @@
+// A minimal DOM, just enough for Render, that counts
+// the children it moves. It is preloaded into node by
+// TestPatchChildrenUnderNode
+"use strict";
+
+globalThis.moves = 0;
+
+class Node {
+	constructor(nodeType, nodeName, namespaceURI) {
+		this.nodeType = nodeType;
+		this.nodeName = nodeName;
+		this.namespaceURI = namespaceURI;
+		this.childNodes = [];
+		this.attributes = [];
+		this.parentNode = null;
+		this.data = "";
+	}
+
+	get children() {
+		return this.childNodes.filter((c) => c.nodeType === 1);
+	}
+
+	getAttribute(name) {
+		const attr = this.attributes.find((a) => a.name === name);
+		return attr ? attr.value : null;
+	}
+
+	setAttribute(name, value) {
+		const attr = this.attributes.find((a) => a.name === name);
+		if (attr) {
+			attr.value = String(value);
+		} else {
+			this.attributes.push({ name: name, value: String(value) });
+		}
+	}
+
+	removeAttribute(name) {
+		this.attributes = this.attributes.filter((a) => a.name !== name);
+	}
+
+	removeChild(child) {
+		this.childNodes.splice(this.childNodes.indexOf(child), 1);
+		child.parentNode = null;
+		return child;
+	}
+
+	insertBefore(child, ref) {
+		if (child.parentNode) {
+			if (child.parentNode === this) {
+				globalThis.moves++;
+			}
+			child.parentNode.removeChild(child);
+		}
+
+		const i = ref ? this.childNodes.indexOf(ref) : -1;
+		if (i < 0) {
+			this.childNodes.push(child);
+		} else {
+			this.childNodes.splice(i, 0, child);
+		}
+
+		child.parentNode = this;
+		return child;
+	}
+
+	appendChild(child) {
+		return this.insertBefore(child, null);
+	}
+}
+
+globalThis.document = {
+	createElement: (tag) => new Node(1, tag.toUpperCase(), "http://www.w3.org/1999/xhtml"),
+	createElementNS: (ns, tag) => new Node(1, tag, ns),
+	createTextNode: (data) => {
+		const n = new Node(3, "#text", null);
+		n.data = data;
+		return n;
+	},
+};
@@ -3,9 +3,10 @@ module github.com/syke99/oasis
 go 1.21
 
 require (
-	github.com/go-chi/chi/v5 v5.0.10 // indirect
-	github.com/google/uuid v1.3.1 // indirect
-	github.com/gorilla/mux v1.8.0 // indirect
-	github.com/yosssi/gohtml v0.0.0-20201013000340-ee4748c638f4 // indirect
-	golang.org/x/net v0.17.0 // indirect
+	github.com/go-chi/chi/v5 v5.0.10
+	github.com/google/uuid v1.3.1
+	github.com/gorilla/mux v1.8.0
+	github.com/yosssi/gohtml v0.0.0-20201013000340-ee4748c638f4
 )
+
+require golang.org/x/net v0.17.0 // indirect
@@ -15,7 +15,7 @@ type Attrs struct {
 	Payload  map[string]any
 }
 
-func Render(t string, data *Attrs) (string, error) {
+func Render(t string, data *Attrs, funcs template.FuncMap) (string, error) {
 	attrs := make(map[string]any)
 
 	attrs["props"] = data.Props
@@ -25,14 +25,28 @@ func Render(t string, data *Attrs) (string, error) {
 		attrs[k] = v
 	}
 
-	temp := template.Must(template.New(fmt.Sprintf("temp-%s", uuid.New().String())).Parse(t))
+	temp := template.Must(Parse(t, funcs))
 
+	return Execute(temp, attrs)
+}
+
+// Parse parses t into a uniquely named
+// *template.Template that can call funcs
+func Parse(t string, funcs template.FuncMap) (*template.Template, error) {
+	return template.New(fmt.Sprintf("temp-%s", uuid.New().String())).Funcs(funcs).Parse(t)
+}
+
+// Execute executes temp with data and
+// returns the formatted output
+func Execute(temp *template.Template, data any) (string, error) {
 	buf := new(bytes.Buffer)
 
-	err := temp.Execute(gohtml.NewWriter(buf), attrs)
+	// gohtml.Writer only flushes once it sees a closing
+	// </html> tag, so format the output of fragments directly
+	err := temp.Execute(buf, data)
 	if err != nil {
 		return "", err
 	}
 
-	return buf.String(), nil
+	return gohtml.Format(buf.String()), nil
 }
@@ -0,0 +1,19 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderFragment(t *testing.T) {
+	out, err := Render(`<div><p>{{ .props.name }}</p></div>`, &Attrs{
+		Props: map[string]any{"name": "oasis"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out, "<p>") || !strings.Contains(out, "oasis") {
+		t.Errorf("expected the rendered fragment, got %q", out)
+	}
+}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"strings"
+)
+
+// ScopeCSS rewrites every selector in css so it only
+// matches inside of the element matched by scope. The
+// :scope pseudo-class is replaced by scope itself so a
+// rule can target the scoped element. Rules nested in
+// @media, @supports, @container and @layer blocks are
+// scoped too; other at-rules are left untouched
+func ScopeCSS(css string, scope string) string {
+	var b strings.Builder
+	scopeRules(&b, css, scope)
+	return b.String()
+}
+
+func scopeRules(b *strings.Builder, css string, scope string) {
+	i := 0
+	for i < len(css) {
+		start := i
+		i = skipSpaceAndComments(css, i)
+		b.WriteString(css[start:i])
+
+		if i >= len(css) {
+			return
+		}
+
+		if css[i] == '}' {
+			// stray closing brace; copy it and move on
+			b.WriteByte('}')
+			i++
+			continue
+		}
+
+		// the prelude is everything up to the rule's
+		// block, or up to ';' for statement at-rules
+		end := indexOutside(css, i, "{;")
+		if end < 0 {
+			b.WriteString(css[i:])
+			return
+		}
+
+		prelude := css[i:end]
+
+		if css[end] == ';' {
+			b.WriteString(css[i : end+1])
+			i = end + 1
+			continue
+		}
+
+		blockEnd := matchingBrace(css, end)
+		block := css[end+1 : blockEnd]
+
+		switch {
+		case strings.HasPrefix(prelude, "@"):
+			b.WriteString(prelude)
+			b.WriteByte('{')
+			if isGroupingRule(prelude) {
+				scopeRules(b, block, scope)
+			} else {
+				b.WriteString(block)
+			}
+		default:
+			b.WriteString(scopeSelectors(prelude, scope))
+			b.WriteByte('{')
+			b.WriteString(block)
+		}
+
+		if blockEnd < len(css) {
+			b.WriteByte('}')
+		}
+
+		i = blockEnd + 1
+	}
+}
+
+func isGroupingRule(prelude string) bool {
+	for _, at := range []string{"@media", "@supports", "@container", "@layer"} {
+		if strings.HasPrefix(strings.ToLower(prelude), at) {
+			return true
+		}
+	}
+	return false
+}
+
+func scopeSelectors(prelude string, scope string) string {
+	trailing := prelude[len(strings.TrimRight(prelude, " \t\r\n\f")):]
+
+	selectors := splitOutside(strings.TrimSpace(prelude), ',')
+	for i, sel := range selectors {
+		sel = strings.TrimSpace(sel)
+
+		switch {
+		case strings.Contains(sel, ":scope"):
+			selectors[i] = strings.ReplaceAll(sel, ":scope", scope)
+		default:
+			selectors[i] = scope + " " + sel
+		}
+	}
+
+	return strings.Join(selectors, ", ") + trailing
+}
+
+func skipSpaceAndComments(css string, i int) int {
+	for i < len(css) {
+		switch {
+		case isSpace(css[i]):
+			i++
+		case strings.HasPrefix(css[i:], "/*"):
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				return len(css)
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// indexOutside returns the index of the first byte in
+// chars found at or after i that isn't inside of a
+// string, comment, or parentheses/brackets
+func indexOutside(css string, i int, chars string) int {
+	depth := 0
+	var quote byte
+	for ; i < len(css); i++ {
+		c := css[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(css[i:], "/*"):
+			end := strings.Index(css[i+2:], "*/")
+			if end < 0 {
+				return -1
+			}
+			i += end + 3
+		case c == '(' || c == '[':
+			depth++
+		case c == ')' || c == ']':
+			depth--
+		case depth == 0 && strings.IndexByte(chars, c) >= 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBrace returns the index of the '}' closing
+// the '{' at open, or len(css) if it is never closed
+func matchingBrace(css string, open int) int {
+	depth := 0
+	i := open
+	for {
+		next := indexOutside(css, i, "{}")
+		if next < 0 {
+			return len(css)
+		}
+
+		if css[next] == '{' {
+			depth++
+		} else {
+			depth--
+		}
+
+		if depth == 0 {
+			return next
+		}
+
+		i = next + 1
+	}
+}
+
+func splitOutside(s string, sep byte) []string {
+	parts := make([]string, 0)
+	for {
+		i := indexOutside(s, 0, string(sep))
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"text/template/parse"
+)
+
+// CheckFields walks the parse tree of temp and makes
+// sure every field it references exists on typ, which
+// is the type the template will be executed with. Named
+// templates, including blocks, are checked against the
+// type of the pipeline they are invoked with. Fields
+// reached through interfaces or function calls can't be
+// resolved statically and are skipped
+func CheckFields(temp *template.Template, typ reflect.Type) error {
+	if temp.Tree == nil || temp.Tree.Root == nil {
+		return nil
+	}
+
+	trees := make(map[string]*parse.Tree)
+	for _, t := range temp.Templates() {
+		if t.Tree != nil {
+			trees[t.Name()] = t.Tree
+		}
+	}
+
+	c := &fieldChecker{
+		vars:    map[string]reflect.Type{"$": typ},
+		trees:   trees,
+		checked: make(map[checkedTree]bool),
+	}
+
+	return c.walk(temp.Tree.Root, typ)
+}
+
+type fieldChecker struct {
+	vars    map[string]reflect.Type
+	trees   map[string]*parse.Tree
+	checked map[checkedTree]bool
+}
+
+// checkedTree is a named template that has been checked
+// against a type, so recursive templates end
+type checkedTree struct {
+	name string
+	typ  reflect.Type
+}
+
+func (c *fieldChecker) walk(node parse.Node, dot reflect.Type) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := c.walk(child, dot); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		_, err := c.pipe(n.Pipe, dot)
+		return err
+	case *parse.IfNode:
+		return c.branch(&n.BranchNode, dot, false)
+	case *parse.RangeNode:
+		return c.branch(&n.BranchNode, dot, true)
+	case *parse.WithNode:
+		return c.branch(&n.BranchNode, dot, false)
+	case *parse.TemplateNode:
+		typ, err := c.pipe(n.Pipe, dot)
+		if err != nil {
+			return err
+		}
+		return c.template(n.Name, typ)
+	}
+
+	return nil
+}
+
+// template checks the named template against typ, the
+// type of the pipeline it is invoked with. It has its
+// own variables, with $ set to its data
+func (c *fieldChecker) template(name string, typ reflect.Type) error {
+	tree, ok := c.trees[name]
+	if !ok || tree.Root == nil || c.checked[checkedTree{name, typ}] {
+		return nil
+	}
+
+	c.checked[checkedTree{name, typ}] = true
+
+	sub := &fieldChecker{
+		vars:    map[string]reflect.Type{"$": typ},
+		trees:   c.trees,
+		checked: c.checked,
+	}
+
+	if err := sub.walk(tree.Root, typ); err != nil {
+		return fmt.Errorf("template %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (c *fieldChecker) branch(b *parse.BranchNode, dot reflect.Type, isRange bool) error {
+	typ, err := c.pipe(b.Pipe, dot)
+	if err != nil {
+		return err
+	}
+
+	inner := dot
+	switch {
+	case isRange:
+		key, elem := rangeTypes(typ)
+		inner = elem
+		switch len(b.Pipe.Decl) {
+		case 1:
+			c.vars[b.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			c.vars[b.Pipe.Decl[0].Ident[0]] = key
+			c.vars[b.Pipe.Decl[1].Ident[0]] = elem
+		}
+	case b.NodeType == parse.NodeWith:
+		inner = typ
+	}
+
+	if err = c.walk(b.List, inner); err != nil {
+		return err
+	}
+
+	return c.walk(b.ElseList, dot)
+}
+
+// pipe checks every command of a pipeline and returns
+// the type it evaluates to, or nil if it can't be known
+func (c *fieldChecker) pipe(p *parse.PipeNode, dot reflect.Type) (reflect.Type, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	var typ reflect.Type
+	for _, cmd := range p.Cmds {
+		typ = nil
+		for _, arg := range cmd.Args {
+			t, err := c.arg(arg, dot)
+			if err != nil {
+				return nil, err
+			}
+			// only a command made of a single field-like
+			// argument has a statically known type
+			if len(cmd.Args) == 1 {
+				typ = t
+			}
+		}
+	}
+
+	for _, v := range p.Decl {
+		if len(v.Ident) > 0 && !p.IsAssign {
+			c.vars[v.Ident[0]] = typ
+		}
+	}
+
+	return typ, nil
+}
+
+func (c *fieldChecker) arg(node parse.Node, dot reflect.Type) (reflect.Type, error) {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot, nil
+	case *parse.FieldNode:
+		return resolve(dot, n.Ident)
+	case *parse.VariableNode:
+		return resolve(c.vars[n.Ident[0]], n.Ident[1:])
+	case *parse.ChainNode:
+		typ, err := c.arg(n.Node, dot)
+		if err != nil {
+			return nil, err
+		}
+		return resolve(typ, n.Field)
+	case *parse.PipeNode:
+		return c.pipe(n, dot)
+	}
+
+	return nil, nil
+}
+
+func resolve(typ reflect.Type, idents []string) (reflect.Type, error) {
+	for _, ident := range idents {
+		if typ == nil {
+			return nil, nil
+		}
+
+		next, err := field(typ, ident)
+		if err != nil {
+			return nil, err
+		}
+
+		typ = next
+	}
+
+	return typ, nil
+}
+
+func field(typ reflect.Type, name string) (reflect.Type, error) {
+	if m, ok := method(typ, name); ok {
+		if m.Type.NumOut() == 0 {
+			return nil, nil
+		}
+		return m.Type.Out(0), nil
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		return nil, nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			break
+		}
+		if typ.Elem().Kind() == reflect.Interface {
+			return nil, nil
+		}
+		return typ.Elem(), nil
+	case reflect.Struct:
+		f, ok := typ.FieldByName(name)
+		if !ok || !f.IsExported() {
+			return nil, fmt.Errorf("template references field %s, which does not exist on type %s", name, typ)
+		}
+		return f.Type, nil
+	}
+
+	return nil, fmt.Errorf("template references field %s on type %s, which has no fields", name, typ)
+}
+
+func method(typ reflect.Type, name string) (reflect.Method, bool) {
+	if m, ok := typ.MethodByName(name); ok {
+		return m, true
+	}
+
+	if typ.Kind() != reflect.Pointer && typ.Kind() != reflect.Interface {
+		return reflect.PointerTo(typ).MethodByName(name)
+	}
+
+	return reflect.Method{}, false
+}
+
+func rangeTypes(typ reflect.Type) (reflect.Type, reflect.Type) {
+	if typ == nil {
+		return nil, nil
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), typ.Elem()
+	case reflect.Map:
+		return typ.Key(), typ.Elem()
+	case reflect.Chan:
+		return nil, typ.Elem()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return typ, typ
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fieldsItem struct {
+	Name string
+}
+
+type fieldsProps struct {
+	Title string
+	Items []fieldsItem
+	Tags  map[string]fieldsItem
+	Owner *fieldsItem
+}
+
+func (p fieldsProps) Upper() string {
+	return strings.ToUpper(p.Title)
+}
+
+func (p *fieldsProps) First() fieldsItem {
+	return p.Items[0]
+}
+
+func TestCheckFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		typ      reflect.Type
+		missing  string
+	}{
+		{
+			name:     "field",
+			template: `{{ .Title }}`,
+		},
+		{
+			name:     "missing field",
+			template: `{{ .Subtitle }}`,
+			missing:  "Subtitle",
+		},
+		{
+			name:     "range",
+			template: `{{ range .Items }}{{ .Name }}{{ end }}`,
+		},
+		{
+			name:     "missing field in range",
+			template: `{{ range .Items }}{{ .Title }}{{ end }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "range variables",
+			template: `{{ range $i, $item := .Items }}{{ $item.Name }}{{ end }}`,
+		},
+		{
+			name:     "missing field of range variable",
+			template: `{{ range $i, $item := .Items }}{{ $item.Label }}{{ end }}`,
+			missing:  "Label",
+		},
+		{
+			name:     "range else",
+			template: `{{ range .Items }}{{ .Name }}{{ else }}{{ .Nope }}{{ end }}`,
+			missing:  "Nope",
+		},
+		{
+			name:     "with",
+			template: `{{ with .Owner }}{{ .Name }}{{ end }}`,
+		},
+		{
+			name:     "missing field in with",
+			template: `{{ with .Owner }}{{ .Title }}{{ end }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "root variable",
+			template: `{{ range .Items }}{{ $.Title }}{{ end }}`,
+		},
+		{
+			name:     "missing field of root variable",
+			template: `{{ range .Items }}{{ $.Name }}{{ end }}`,
+			missing:  "Name",
+		},
+		{
+			name:     "variable",
+			template: `{{ $owner := .Owner }}{{ $owner.Name }}`,
+		},
+		{
+			name:     "missing field of variable",
+			template: `{{ $owner := .Owner }}{{ $owner.Title }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "method",
+			template: `{{ .Upper }}`,
+		},
+		{
+			name:     "pointer method",
+			template: `{{ .First.Name }}`,
+		},
+		{
+			name:     "missing field of method result",
+			template: `{{ .First.Title }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "map",
+			template: `{{ .Tags.featured.Name }}`,
+		},
+		{
+			name:     "missing field of map element",
+			template: `{{ .Tags.featured.Title }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "pointer props",
+			template: `{{ .Title }}{{ .Owner.Name }}`,
+			typ:      reflect.TypeOf(&fieldsProps{}),
+		},
+		{
+			name:     "missing field of pointer props",
+			template: `{{ .Owner.Title }}`,
+			typ:      reflect.TypeOf(&fieldsProps{}),
+			missing:  "Title",
+		},
+		{
+			name:     "block",
+			template: `{{ block "items" .Items }}{{ range . }}{{ .Name }}{{ end }}{{ end }}`,
+		},
+		{
+			name:     "missing field in block",
+			template: `{{ block "items" .Items }}{{ range . }}{{ .Title }}{{ end }}{{ end }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "define",
+			template: `{{ define "owner" }}{{ .Name }}{{ end }}{{ template "owner" .Owner }}`,
+		},
+		{
+			name:     "missing field in define",
+			template: `{{ define "owner" }}{{ .Title }}{{ end }}{{ template "owner" .Owner }}`,
+			missing:  "Title",
+		},
+		{
+			name:     "root variable in define",
+			template: `{{ define "owner" }}{{ $.Name }}{{ end }}{{ template "owner" .Owner }}`,
+		},
+		{
+			name:     "recursive define",
+			template: `{{ define "items" }}{{ range . }}{{ .Name }}{{ template "items" $ }}{{ end }}{{ end }}{{ template "items" .Items }}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			temp, err := Parse(tt.template, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			typ := tt.typ
+			if typ == nil {
+				typ = reflect.TypeOf(fieldsProps{})
+			}
+
+			err = CheckFields(temp, typ)
+			switch {
+			case tt.missing == "" && err != nil:
+				t.Errorf("expected no error, got %v", err)
+			case tt.missing != "" && err == nil:
+				t.Errorf("expected %s to be reported missing", tt.missing)
+			case tt.missing != "" && !strings.Contains(err.Error(), tt.missing):
+				t.Errorf("expected %s to be reported missing, got %v", tt.missing, err)
+			}
+		})
+	}
+}
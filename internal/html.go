@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"html"
+	"strings"
+)
+
+// SetRootAttr sets the attribute name to val on the
+// first element of markup. If the element already has
+// the attribute, markup is returned unchanged. If markup
+// doesn't start with an element, it is wrapped in a
+// <div> carrying the attribute
+func SetRootAttr(markup string, name string, val string) string {
+	attr := " " + name + `="` + html.EscapeString(val) + `"`
+
+	i := 0
+	for i < len(markup) {
+		switch {
+		case isSpace(markup[i]):
+			i++
+		case strings.HasPrefix(markup[i:], "<!--"):
+			end := strings.Index(markup[i:], "-->")
+			if end < 0 {
+				return wrap(markup, attr)
+			}
+			i += end + len("-->")
+		case strings.HasPrefix(markup[i:], "<!"):
+			end := strings.IndexByte(markup[i:], '>')
+			if end < 0 {
+				return wrap(markup, attr)
+			}
+			i += end + 1
+		case markup[i] == '<' && i+1 < len(markup) && isLetter(markup[i+1]):
+			j := i + 1
+			for j < len(markup) && !isSpace(markup[j]) && markup[j] != '>' && markup[j] != '/' {
+				j++
+			}
+
+			if hasAttr(markup[j:tagEnd(markup, j)], name) {
+				return markup
+			}
+
+			return markup[:j] + attr + markup[j:]
+		default:
+			return wrap(markup, attr)
+		}
+	}
+
+	return wrap(markup, attr)
+}
+
+func wrap(markup string, attr string) string {
+	return "<div" + attr + ">" + markup + "</div>"
+}
+
+// tagEnd returns the index of the '>' closing
+// the tag whose attributes start at i
+func tagEnd(markup string, i int) int {
+	var quote byte
+	for ; i < len(markup); i++ {
+		c := markup[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return len(markup)
+}
+
+func hasAttr(attrs string, name string) bool {
+	for _, field := range strings.Fields(attrs) {
+		key, _, _ := strings.Cut(field, "=")
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// RPCRequest is the body of a call
+// to a method of an RPC interface
+type RPCRequest struct {
+	Args []json.RawMessage `json:"args"`
+}
+
+// RPCResponse is the body of the response
+// to a call to a method of an RPC interface
+type RPCResponse struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// RPCMethod describes a method of an RPC interface
+type RPCMethod struct {
+	Name string
+	// Type is the method's signature, without a receiver
+	Type reflect.Type
+	// HasContext is true if the method's
+	// first argument is a context.Context
+	HasContext bool
+	// Args are the types of the arguments sent
+	// over the wire, not including the context
+	Args []reflect.Type
+	// Result is the type of the non-error result,
+	// or nil if the method only returns an error
+	Result reflect.Type
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// RPCMethods returns the methods of iface, which must be
+// an interface type. Every method may take a context.Context
+// as its first argument and must return either an error, or
+// a result followed by an error
+func RPCMethods(iface reflect.Type) ([]RPCMethod, error) {
+	if iface.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("rpc: %s is not an interface", iface)
+	}
+
+	methods := make([]RPCMethod, iface.NumMethod())
+
+	for i := 0; i < iface.NumMethod(); i++ {
+		m := iface.Method(i)
+		if !m.IsExported() {
+			return nil, fmt.Errorf("rpc: method %s of %s is not exported", m.Name, iface)
+		}
+
+		method, err := NewRPCMethod(m.Name, m.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		methods[i] = method
+	}
+
+	return methods, nil
+}
+
+// NewRPCMethod describes the method with the given
+// name and signature, or returns an error if its
+// signature can't be called over RPC
+func NewRPCMethod(name string, typ reflect.Type) (RPCMethod, error) {
+	m := RPCMethod{
+		Name: name,
+		Type: typ,
+	}
+
+	if typ.IsVariadic() {
+		return m, fmt.Errorf("rpc: method %s cannot be variadic", name)
+	}
+
+	for i := 0; i < typ.NumIn(); i++ {
+		in := typ.In(i)
+		if i == 0 && in == contextType {
+			m.HasContext = true
+			continue
+		}
+		m.Args = append(m.Args, in)
+	}
+
+	switch {
+	case typ.NumOut() == 1 && typ.Out(0) == errorType:
+	case typ.NumOut() == 2 && typ.Out(1) == errorType:
+		m.Result = typ.Out(0)
+	default:
+		return m, fmt.Errorf("rpc: method %s must return an error, or a result and an error", name)
+	}
+
+	return m, nil
+}
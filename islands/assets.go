@@ -0,0 +1,234 @@
+package islands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/syke99/oasis/internal"
+	"html"
+	"html/template"
+	"strings"
+)
+
+// ScopeAttr is the attribute set on the root element of
+// an Island with scoped styles; its styles only apply
+// inside of the element carrying it
+const ScopeAttr = "data-oasis-scope"
+
+type AssetKind string
+
+const (
+	StyleAsset  AssetKind = "style"
+	ScriptAsset AssetKind = "script"
+)
+
+// Asset is a stylesheet or script an Island needs
+// in order to be displayed. An Asset is either
+// inline, with its contents in Inline, or file-based,
+// with the URL of the file in Src
+type Asset struct {
+	Kind   AssetKind
+	Src    string
+	Inline string
+}
+
+// HTML returns the tag that loads an Asset
+func (a Asset) HTML() template.HTML {
+	switch {
+	case a.Kind == StyleAsset && a.Src != "":
+		return template.HTML(fmt.Sprintf(`<link rel="stylesheet" href="%s">`, html.EscapeString(a.Src)))
+	case a.Kind == StyleAsset:
+		return template.HTML("<style>" + a.Inline + "</style>")
+	case a.Src != "":
+		return template.HTML(fmt.Sprintf(`<script src="%s"></script>`, html.EscapeString(a.Src)))
+	default:
+		return template.HTML("<script>" + a.Inline + "</script>")
+	}
+}
+
+func (a Asset) key() string {
+	if a.Src != "" {
+		return string(a.Kind) + ":src:" + a.Src
+	}
+
+	sum := sha256.Sum256([]byte(a.Inline))
+	return string(a.Kind) + ":inline:" + hex.EncodeToString(sum[:])
+}
+
+// AssetsHTML returns the tags loading assets, in order
+func AssetsHTML(assets []Asset) template.HTML {
+	var b strings.Builder
+	for _, a := range assets {
+		b.WriteString(string(a.HTML()))
+	}
+	return template.HTML(b.String())
+}
+
+// scopeID returns the value of ScopeAttr for
+// the Island with the given name
+func scopeID(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return "o-" + hex.EncodeToString(sum[:4])
+}
+
+func scopeSelector(name string) string {
+	return fmt.Sprintf(`[%s="%s"]`, ScopeAttr, scopeID(name))
+}
+
+// collector gathers the deduplicated assets of
+// every Island rendered for a single response.
+// A nil *collector discards everything added to it
+type collector struct {
+	seen   map[string]bool
+	assets []Asset
+	// included is set once an Island is rendered
+	// from a template via {{ island }}
+	included bool
+}
+
+func newCollector() *collector {
+	return &collector{
+		seen: make(map[string]bool),
+	}
+}
+
+// include records that an Island was
+// rendered from a template via {{ island }}
+func (c *collector) include() {
+	if c != nil {
+		c.included = true
+	}
+}
+
+func (c *collector) add(assets ...Asset) {
+	if c == nil {
+		return
+	}
+
+	for _, a := range assets {
+		k := a.key()
+		if c.seen[k] {
+			continue
+		}
+		c.seen[k] = true
+		c.assets = append(c.assets, a)
+	}
+}
+
+// renderCollect renders island, adding the assets of
+// it and every Island rendered along with it to col
+func renderCollect(island Island, col *collector) (string, error) {
+	if n, ok := island.(*node); ok {
+		return n.renderCollect(col)
+	}
+
+	str, err := island.Render()
+	if err != nil {
+		return "", err
+	}
+
+	col.add(island.GetAssets()...)
+
+	return str, nil
+}
+
+// lazyChild is how a child Island that wasn't
+// prerendered is exposed to its parent's template,
+// so it can be rendered via {{ .(child name).Render }}
+type lazyChild struct {
+	child Island
+	col   *collector
+}
+
+// Render renders the child Island
+func (l lazyChild) Render() (template.HTML, error) {
+	str, err := renderCollect(l.child, l.col)
+	return template.HTML(str), err
+}
+
+// Layout is the page a response's Islands are
+// rendered into when a full page is requested.
+// Its template can access the collected assets via
+// {{ .assets }}, the main Island via {{ .content }}
+// and any out-of-band Islands via {{ .oob.(name) }}
+type Layout struct {
+	template string
+}
+
+func NewLayout(template string) *Layout {
+	return &Layout{
+		template: template,
+	}
+}
+
+// GetTemplate returns the template of a Layout
+func (l *Layout) GetTemplate() string {
+	return l.template
+}
+
+// assetsPlaceholder stands in for {{ .assets }} while
+// a Layout is executed, since Islands rendered by the
+// Layout itself via {{ island }} can add assets after
+// {{ .assets }} has already been written
+const assetsPlaceholder = "<!--oasis:assets-->"
+
+// RenderPage renders main along with any out-of-band
+// Islands using the DefaultRegistry; see Registry.RenderPage
+func RenderPage(layout *Layout, main Island, oob ...Island) (string, error) {
+	return DefaultRegistry.RenderPage(layout, main, oob...)
+}
+
+// RenderPage renders main along with any out-of-band
+// Islands and the deduplicated assets of only the Islands
+// that were actually rendered. If layout is nil, a fragment
+// is returned for htmx to swap in: the asset tags followed
+// by main and then the out-of-band Islands, each marked with
+// hx-swap-oob. Otherwise, everything is rendered into layout,
+// which can render r's Islands via {{ island }}
+func (r *Registry) RenderPage(layout *Layout, main Island, oob ...Island) (string, error) {
+	col := newCollector()
+
+	content, err := renderCollect(main, col)
+	if err != nil {
+		return "", err
+	}
+
+	oobs := make([]string, len(oob))
+	for i := range oob {
+		oobs[i], err = renderCollect(oob[i], col)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if layout == nil {
+		var b strings.Builder
+		b.WriteString(string(AssetsHTML(col.assets)))
+		b.WriteString(content)
+		for i := range oobs {
+			b.WriteString(internal.SetRootAttr(oobs[i], "hx-swap-oob", "true"))
+		}
+		return b.String(), nil
+	}
+
+	oobMap := make(map[string]any, len(oob))
+	for i := range oob {
+		oobMap[oob[i].GetName()] = template.HTML(oobs[i])
+	}
+
+	temp, err := internal.Parse(layout.template, r.funcMap(col))
+	if err != nil {
+		return "", err
+	}
+
+	page, err := internal.Execute(temp, map[string]any{
+		"assets":  template.HTML(assetsPlaceholder),
+		"content": template.HTML(content),
+		"oob":     oobMap,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ReplaceAll(page, assetsPlaceholder, string(AssetsHTML(col.assets))), nil
+}
@@ -0,0 +1,62 @@
+package islands
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRenderPageLayoutIslands(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(NewIsland("nav", `<nav>nav</nav>`).AddStyle(`nav { color: red; }`))
+
+	main := NewIsland("main", `<main>main</main>`)
+	layout := NewLayout(`<html><head>{{ .assets }}</head><body>{{ island "nav" }}{{ .content }}</body></html>`)
+
+	out, err := r.RenderPage(layout, main)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out, "<nav") {
+		t.Errorf("expected the layout's island from the registry, got %q", out)
+	}
+	if !strings.Contains(out, scopeSelector("nav")) {
+		t.Errorf("expected the layout's island's assets to be collected, got %q", out)
+	}
+	if strings.Contains(out, assetsPlaceholder) {
+		t.Errorf("expected the assets placeholder to be replaced, got %q", out)
+	}
+	if strings.Index(out, "<style>") > strings.Index(out, "<body>") {
+		t.Errorf("expected the assets to be rendered where {{ .assets }} is, got %q", out)
+	}
+}
+
+func TestAddStyleSheetScoped(t *testing.T) {
+	fsys := fstest.MapFS{
+		"card.css": {Data: []byte(`p { color: red; }`)},
+	}
+
+	island := NewIsland("card", `<div><p>card</p></div>`).AddStyleSheet(fsys, "card.css")
+
+	assets := island.GetAssets()
+	if len(assets) != 1 || assets[0].Src != "" {
+		t.Fatalf("expected one inline stylesheet, got %+v", assets)
+	}
+	if !strings.Contains(assets[0].Inline, scopeSelector("card")) {
+		t.Errorf("expected the stylesheet to be scoped, got %q", assets[0].Inline)
+	}
+
+	out, err := island.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, ScopeAttr) {
+		t.Errorf("expected the root element to carry %s, got %q", ScopeAttr, out)
+	}
+
+	missing := NewIsland("missing", `<div></div>`).AddStyleSheet(fsys, "missing.css")
+	if _, err = missing.Render(); err == nil {
+		t.Error("expected a missing stylesheet to fail rendering")
+	}
+}
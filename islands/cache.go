@@ -0,0 +1,294 @@
+package islands
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of rendered Islands
+// a Cache created without a CacheStore holds before
+// evicting the least recently used one
+const DefaultCacheSize = 1024
+
+// CacheEntry is a rendered Island held in a CacheStore
+type CacheEntry struct {
+	// Name is the name of the Island that was rendered
+	Name string
+	// Tags are the tags the Island was cached with
+	Tags []string
+	// Value is the rendered template
+	Value string
+	// Expires is when the entry stops being valid;
+	// the zero value means it never expires
+	Expires time.Time
+	// Assets are the assets of every Island
+	// that was rendered along with it
+	Assets []Asset
+}
+
+// CacheStore is the storage backing a Cache. Implement
+// it to keep rendered Islands somewhere other than in
+// memory. A CacheStore must be safe for concurrent use
+type CacheStore interface {
+	// Get returns the entry stored under key
+	Get(key string) (*CacheEntry, bool)
+	// Set stores entry under key
+	Set(key string, entry *CacheEntry)
+	// Delete removes the entry stored under key
+	Delete(key string)
+	// Range calls fn for every stored entry
+	// until fn returns false
+	Range(fn func(key string, entry *CacheEntry) bool)
+}
+
+// Cache holds rendered Islands keyed by their
+// name and a hash of their props and payload so
+// that Islands rendering identical output for
+// identical input only get rendered once
+type Cache struct {
+	store CacheStore
+}
+
+// NewCache creates a *Cache backed by store. If
+// store is nil, a MemoryStore holding up to
+// DefaultCacheSize entries is used
+func NewCache(store CacheStore) *Cache {
+	if store == nil {
+		store = NewMemoryStore(DefaultCacheSize)
+	}
+
+	return &Cache{
+		store: store,
+	}
+}
+
+func (c *Cache) get(key string) (*CacheEntry, bool) {
+	entry, ok := c.store.Get(key)
+	if !ok {
+		return nil, false
+	}
+
+	if !entry.Expires.IsZero() && time.Now().After(entry.Expires) {
+		c.store.Delete(key)
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (c *Cache) set(key string, entry *CacheEntry, ttl time.Duration) {
+	if ttl > 0 {
+		entry.Expires = time.Now().Add(ttl)
+	}
+
+	c.store.Set(key, entry)
+}
+
+// InvalidateName removes every rendered
+// Island with the given name from c
+func (c *Cache) InvalidateName(name string) {
+	c.invalidate(func(entry *CacheEntry) bool {
+		return entry.Name == name
+	})
+}
+
+// InvalidateTag removes every rendered Island
+// that was cached with the given tag from c
+func (c *Cache) InvalidateTag(tag string) {
+	c.invalidate(func(entry *CacheEntry) bool {
+		for _, t := range entry.Tags {
+			if t == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Purge removes every rendered Island from c
+func (c *Cache) Purge() {
+	c.invalidate(func(*CacheEntry) bool {
+		return true
+	})
+}
+
+func (c *Cache) invalidate(match func(entry *CacheEntry) bool) {
+	keys := make([]string, 0)
+
+	c.store.Range(func(key string, entry *CacheEntry) bool {
+		if match(entry) {
+			keys = append(keys, key)
+		}
+		return true
+	})
+
+	for _, key := range keys {
+		c.store.Delete(key)
+	}
+}
+
+// MemoryStore is an in-memory CacheStore that
+// evicts the least recently used entry once it
+// holds more than its maximum number of entries
+type MemoryStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+type memoryItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewMemoryStore creates a *MemoryStore holding up
+// to maxEntries entries. If maxEntries is 0 or less,
+// entries are never evicted
+func NewMemoryStore(maxEntries int) *MemoryStore {
+	return &MemoryStore{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Get returns the entry stored under key
+// and marks it as recently used
+func (m *MemoryStore) Get(key string) (*CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.ll.MoveToFront(el)
+
+	return el.Value.(*memoryItem).entry, true
+}
+
+// Set stores entry under key, evicting the least
+// recently used entry if m is full
+func (m *MemoryStore) Set(key string, entry *CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryItem).entry = entry
+		m.ll.MoveToFront(el)
+		return
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryItem{key: key, entry: entry})
+
+	if m.maxEntries > 0 && m.ll.Len() > m.maxEntries {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryItem).key)
+	}
+}
+
+// Delete removes the entry stored under key
+func (m *MemoryStore) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.ll.Remove(el)
+		delete(m.items, key)
+	}
+}
+
+// Range calls fn for every stored entry,
+// from most to least recently used, until
+// fn returns false
+func (m *MemoryStore) Range(fn func(key string, entry *CacheEntry) bool) {
+	m.mu.Lock()
+	items := make([]*memoryItem, 0, m.ll.Len())
+	for el := m.ll.Front(); el != nil; el = el.Next() {
+		items = append(items, el.Value.(*memoryItem))
+	}
+	m.mu.Unlock()
+
+	for _, item := range items {
+		if !fn(item.key, item.entry) {
+			return
+		}
+	}
+}
+
+// Len returns the number of entries stored in m
+func (m *MemoryStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.ll.Len()
+}
+
+type cacheOpts struct {
+	cache *Cache
+	ttl   time.Duration
+	tags  []string
+}
+
+// cacheKey returns the key n's rendered template is
+// cached under, which covers everything that changes
+// its output: its template, props, payload, children,
+// assets and whether it is scoped or interactive. It
+// is false if any of them can't be hashed, in which
+// case n is not cached
+func (n *node) cacheKey() (string, bool) {
+	in, ok := n.cacheInput()
+	if !ok {
+		return "", false
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256(b)
+
+	return n.name + ":" + hex.EncodeToString(sum[:]), true
+}
+
+func (n *node) cacheInput() (map[string]any, bool) {
+	children := make(map[string]any, len(n.children))
+
+	for name, child := range n.children {
+		c, ok := child.child.(*node)
+		if !ok {
+			return nil, false
+		}
+
+		in, ok := c.cacheInput()
+		if !ok {
+			return nil, false
+		}
+
+		in["prerender"] = child.prerender
+		children[name] = in
+	}
+
+	var props any = n.props.props
+	if n.typed != nil {
+		props = n.typed.props
+	}
+
+	return map[string]any{
+		"template":    n.template,
+		"props":       props,
+		"payload":     n.payload,
+		"children":    children,
+		"assets":      n.assets,
+		"scoped":      n.scoped,
+		"interactive": n.interactive,
+	}, true
+}
@@ -0,0 +1,146 @@
+package islands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheRender(t *testing.T) {
+	store := NewMemoryStore(0)
+	cache := NewCache(store)
+
+	island := NewIsland("count", `<p>{{ .props.n }}</p>`)
+	island.AddProp("n", 1)
+	island.(Cacheable).Cache(cache, 0, "counts")
+
+	for i := 0; i < 2; i++ {
+		out, err := island.Render()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out, "1") {
+			t.Errorf("expected 1, got %q", out)
+		}
+	}
+	if store.Len() != 1 {
+		t.Errorf("expected 1 entry, got %d", store.Len())
+	}
+
+	island.AddProp("n", 2)
+	out, _ := island.Render()
+	if !strings.Contains(out, "2") {
+		t.Errorf("expected changed props to be rendered, got %q", out)
+	}
+	if store.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", store.Len())
+	}
+
+	cache.InvalidateTag("counts")
+	if store.Len() != 0 {
+		t.Errorf("expected the tag to be invalidated, got %d entries", store.Len())
+	}
+}
+
+func TestCacheExpires(t *testing.T) {
+	store := NewMemoryStore(0)
+	cache := NewCache(store)
+
+	cache.set("k", &CacheEntry{Name: "k"}, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	if _, ok := cache.get("k"); ok {
+		t.Error("expected the entry to have expired")
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected the expired entry to be deleted, got %d entries", store.Len())
+	}
+}
+
+func TestMemoryStoreEvicts(t *testing.T) {
+	store := NewMemoryStore(2)
+
+	store.Set("a", &CacheEntry{})
+	store.Set("b", &CacheEntry{})
+	store.Get("a")
+	store.Set("c", &CacheEntry{})
+
+	if _, ok := store.Get("b"); ok {
+		t.Error("expected the least recently used entry to be evicted")
+	}
+	if _, ok := store.Get("a"); !ok {
+		t.Error("expected a recently used entry to be kept")
+	}
+}
+
+func TestCacheNil(t *testing.T) {
+	island := NewIsland("nil", `<p>nil</p>`)
+	island.(Cacheable).Cache(nil, time.Minute)
+
+	if _, err := island.Render(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCacheSkipsIncludes(t *testing.T) {
+	r := NewRegistry()
+
+	child := NewIsland("child", `<span>{{ .props.v }}</span>`)
+	child.AddProp("v", "old")
+
+	store := NewMemoryStore(0)
+	parent := NewIsland("parent", `<div>{{ island "child" }}</div>`)
+	parent.(Cacheable).Cache(NewCache(store), 0)
+
+	r.MustRegister(child, parent)
+
+	parent.Render()
+	child.AddProp("v", "new")
+
+	out, err := parent.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "new") {
+		t.Errorf("expected the included island to be rendered again, got %q", out)
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected the parent not to be cached, got %d entries", store.Len())
+	}
+}
+
+func TestCacheKeyCoversSettings(t *testing.T) {
+	cache := NewCache(nil)
+
+	render := func(island Island) string {
+		island.(Cacheable).Cache(cache, 0)
+
+		out, err := island.Render()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	render(NewIsland("card", `<div>card</div>`))
+
+	out := render(NewIsland("card", `<div>card</div>`).Interactive())
+	if !strings.Contains(out, IslandAttr) {
+		t.Errorf("expected the interactive island not to hit the cache, got %q", out)
+	}
+
+	out = render(NewIsland("card", `<div>card</div>`).AddStyle(`div { color: red; }`))
+	if !strings.Contains(out, ScopeAttr) {
+		t.Errorf("expected the scoped island not to hit the cache, got %q", out)
+	}
+
+	page, err := NewRegistry().RenderPage(nil,
+		NewIsland("card", `<div>card</div>`).AddScriptSrc("/card.js").(Cacheable).Cache(cache, 0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(page, "/card.js") {
+		t.Errorf("expected the island's script not to be dropped by the cache, got %q", page)
+	}
+}
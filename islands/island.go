@@ -2,7 +2,22 @@ package islands
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/syke99/oasis/internal"
+	"html/template"
+	"io/fs"
+	"sort"
+	"time"
+)
+
+const (
+	// IslandAttr is the attribute holding the name of
+	// an interactive Island on its root element
+	IslandAttr = "data-oasis-island"
+	// PropsAttr is the attribute holding the props of
+	// an interactive Island, serialized as JSON, on its
+	// root element
+	PropsAttr = "data-oasis-props"
 )
 
 type props struct {
@@ -43,12 +58,52 @@ type Island interface {
 	// HydrateBytes is just like Hydrate, except it accepts
 	// a []byte representation of a JSON object
 	HydrateBytes(payload []byte) (Island, error)
+	// AddStyle adds inline CSS to an Island. Its rules are
+	// scoped to the Island's root element, which can itself
+	// be targeted with the :scope pseudo-class
+	AddStyle(css string) Island
+	// AddStyleSheet adds the stylesheet stored at name in
+	// fsys to an Island. Like with AddStyle, its rules are
+	// scoped to the Island's root element. If it can't be
+	// read, rendering the Island fails
+	AddStyleSheet(fsys fs.FS, name string) Island
+	// AddScript adds an inline script to an Island
+	AddScript(js string) Island
+	// AddScriptSrc adds the script at src to an Island
+	AddScriptSrc(src string) Island
+	// GetAssets returns the stylesheets and scripts
+	// that were added to an Island
+	GetAssets() []Asset
+	// Interactive marks an Island as interactive, so its
+	// root element is rendered with its name in IslandAttr
+	// and its props serialized as JSON in PropsAttr. This
+	// lets the client hydrate it with Go behavior
+	Interactive() Island
 	// Render renders an Island's template and returns
 	// the rendered template string or an error if one
 	// occurs
 	Render() (string, error)
 }
 
+// Cacheable is implemented by Islands that can cache
+// their rendered templates, like those created with
+// NewIsland, e.g.
+//
+//	island.(islands.Cacheable).Cache(cache, time.Minute)
+type Cacheable interface {
+	Island
+	// Cache opts an Island into caching its rendered
+	// template in cache, or in a new Cache of its own if
+	// cache is nil, for ttl, keyed by its name and a hash
+	// of its props, payload, children, assets and whether
+	// it is interactive. A ttl of 0 keeps it until it is
+	// evicted or invalidated by name or by one of its tags. Islands whose templates
+	// render other Islands via {{ island }}, directly or
+	// through their children, are never cached, since
+	// those Islands can change without their keys changing
+	Cache(cache *Cache, ttl time.Duration, tags ...string) Island
+}
+
 // MustRender attempts to render an Island
 // and returns the rendered template. It
 // panics if an error is encountered
@@ -61,11 +116,18 @@ func MustRender(elem Island) string {
 }
 
 type node struct {
-	name     string
-	template string
-	props    *props
-	children map[string]chld
-	payload  map[string]any
+	name        string
+	template    string
+	props       *props
+	typed       *typedProps
+	children    map[string]chld
+	payload     map[string]any
+	registry    *Registry
+	cache       *cacheOpts
+	assets      []Asset
+	scoped      bool
+	interactive bool
+	err         error
 }
 
 type chld struct {
@@ -97,6 +159,9 @@ func (n *node) GetTemplate() string {
 // GetProps returns the props for a given
 // Island as a map[string]any
 func (n *node) GetProps() map[string]any {
+	if n.typed != nil {
+		return n.typed.propsMap()
+	}
 	return n.props.props
 }
 
@@ -160,30 +225,214 @@ func (n *node) HydrateBytes(payload []byte) (Island, error) {
 	return n, nil
 }
 
+// Cache opts an Island into caching its rendered
+// template; see Cacheable
+func (n *node) Cache(cache *Cache, ttl time.Duration, tags ...string) Island {
+	if cache == nil {
+		cache = NewCache(nil)
+	}
+
+	n.cache = &cacheOpts{
+		cache: cache,
+		ttl:   ttl,
+		tags:  tags,
+	}
+	return n
+}
+
+// AddStyle adds inline CSS to an Island. Its rules are
+// scoped to the Island's root element, which can itself
+// be targeted with the :scope pseudo-class
+func (n *node) AddStyle(css string) Island {
+	n.scoped = true
+	n.assets = append(n.assets, Asset{
+		Kind:   StyleAsset,
+		Inline: internal.ScopeCSS(css, scopeSelector(n.name)),
+	})
+	return n
+}
+
+// AddStyleSheet adds the stylesheet stored at name in
+// fsys to an Island. Like with AddStyle, its rules are
+// scoped to the Island's root element. If it can't be
+// read, rendering the Island fails
+func (n *node) AddStyleSheet(fsys fs.FS, name string) Island {
+	css, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		n.err = fmt.Errorf("island %s: %w", n.name, err)
+		return n
+	}
+
+	return n.AddStyle(string(css))
+}
+
+// AddScript adds an inline script to an Island
+func (n *node) AddScript(js string) Island {
+	n.assets = append(n.assets, Asset{
+		Kind:   ScriptAsset,
+		Inline: js,
+	})
+	return n
+}
+
+// AddScriptSrc adds the script at src to an Island
+func (n *node) AddScriptSrc(src string) Island {
+	n.assets = append(n.assets, Asset{
+		Kind: ScriptAsset,
+		Src:  src,
+	})
+	return n
+}
+
+// GetAssets returns the stylesheets and scripts
+// that were added to an Island
+func (n *node) GetAssets() []Asset {
+	return n.assets
+}
+
+// Interactive marks an Island as interactive, so its
+// root element is rendered with its name in IslandAttr
+// and its props serialized as JSON in PropsAttr. This
+// lets the client hydrate it with Go behavior
+func (n *node) Interactive() Island {
+	n.interactive = true
+	return n
+}
+
 // Render renders an Island's template and returns
 // the rendered template string or an error if one
 // occurs
 func (n *node) Render() (string, error) {
+	return n.renderCollect(nil)
+}
+
+func (n *node) renderCollect(col *collector) (string, error) {
+	if n.cache == nil {
+		return n.render(col)
+	}
+
+	key, ok := n.cacheKey()
+	if !ok {
+		return n.render(col)
+	}
+
+	if entry, ok := n.cache.cache.get(key); ok {
+		col.add(entry.Assets...)
+		return entry.Value, nil
+	}
+
+	// collect separately so a cache hit can
+	// report the assets of everything rendered
+	sub := newCollector()
+
+	str, err := n.render(sub)
+	if err != nil {
+		return "", err
+	}
+
+	col.add(sub.assets...)
+
+	if sub.included {
+		col.include()
+		return str, nil
+	}
+
+	n.cache.cache.set(key, &CacheEntry{
+		Name:   n.name,
+		Tags:   n.cache.tags,
+		Value:  str,
+		Assets: sub.assets,
+	}, n.cache.ttl)
+
+	return str, nil
+}
+
+func (n *node) render(col *collector) (string, error) {
+	if n.err != nil {
+		return "", n.err
+	}
+
+	col.add(n.assets...)
+
 	childMap := make(map[string]any)
 
-	for name, child := range n.children {
+	// render children in a fixed order so that
+	// their assets are collected in the same
+	// order on the server and on the client
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := n.children[name]
 		if child.prerender {
-			renderedChild, err := child.child.Render()
+			renderedChild, err := renderCollect(child.child, col)
 			if err != nil {
 				return "", err
 			}
 
-			childMap[name] = renderedChild
+			childMap[name] = template.HTML(renderedChild)
 			continue
 		}
 
-		childMap[name] = child
+		childMap[name] = lazyChild{
+			child: child.child,
+			col:   col,
+		}
+	}
+
+	var str string
+	var err error
+	if n.typed != nil {
+		str, err = n.typed.render(n.getRegistry().funcMap(col))
+	} else {
+		p := &internal.Attrs{
+			Props:    n.props.props,
+			Children: childMap,
+			Payload:  n.payload,
+		}
+
+		str, err = internal.Render(n.template, p, n.getRegistry().funcMap(col))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if n.scoped {
+		str = internal.SetRootAttr(str, ScopeAttr, scopeID(n.name))
+	}
+
+	if n.interactive {
+		var props []byte
+		if n.typed != nil {
+			props, err = json.Marshal(n.typed.props)
+		} else {
+			props, err = json.Marshal(n.props.props)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		str = internal.SetRootAttr(str, IslandAttr, n.name)
+		str = internal.SetRootAttr(str, PropsAttr, string(props))
 	}
 
-	p := &internal.Attrs{
-		Props:    n.props.props,
-		Children: childMap,
-		Payload:  n.payload,
+	return str, nil
+}
+
+// renderWithPayload renders a copy of n with the
+// given payload, leaving n's own payload untouched
+func (n *node) renderWithPayload(payload map[string]any, col *collector) (string, error) {
+	cp := *n
+	cp.payload = payload
+	return cp.renderCollect(col)
+}
+
+func (n *node) getRegistry() *Registry {
+	if n.registry != nil {
+		return n.registry
 	}
-	return internal.Render(n.template, p)
+	return DefaultRegistry
 }
@@ -0,0 +1,107 @@
+package islands
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// printRenderEnv makes TestPrintRender print the
+// rendered Island so it can be compared across targets
+const printRenderEnv = "OASIS_PRINT_RENDER"
+
+var (
+	renderBegin = []byte("--- oasis render begin ---")
+	renderEnd   = []byte("--- oasis render end ---")
+)
+
+func isomorphicIsland() Island {
+	badge := NewIsland("badge", `<span class="badge">{{ .props.label }}</span>`).
+		AddProp("label", "new").
+		AddStyle(`span { font-weight: bold; }`)
+
+	card := NewIsland("card", `<div class="card"><h2>{{ .props.title }}</h2>{{ .badge }}<p>{{ .payload.body }}</p><ul>{{ range .props.items }}<li>{{ . }}</li>{{ end }}</ul></div>`).
+		AddProps(map[string]any{
+			"title": "Oasis & <friends>",
+			"items": []string{"one", "two", "three"},
+		}).
+		Hydrate(map[string]any{"body": "rendered on both sides"}).
+		AddStyle(`h2 { margin: 0; } :scope { display: block; }`).
+		Interactive()
+	card.AddChild(badge, true)
+
+	return card
+}
+
+func renderIsomorphic() ([]byte, error) {
+	page, err := NewRegistry().RenderPage(nil, isomorphicIsland())
+	return []byte(page), err
+}
+
+// TestPrintRender prints the rendered Island when
+// run by TestRenderMatchesWasm under js/wasm
+func TestPrintRender(t *testing.T) {
+	if os.Getenv(printRenderEnv) == "" {
+		t.Skip("only run by TestRenderMatchesWasm")
+	}
+
+	out, err := renderIsomorphic()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fmt.Printf("%s%s%s\n", renderBegin, base64.StdEncoding.EncodeToString(out), renderEnd)
+}
+
+func TestRenderMatchesWasm(t *testing.T) {
+	if runtime.GOOS == "js" {
+		t.Skip("compares against js/wasm from the host")
+	}
+	if testing.Short() {
+		t.Skip("builds and runs the package under js/wasm")
+	}
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is needed to run js/wasm")
+	}
+
+	execPath := filepath.Join(runtime.GOROOT(), "lib", "wasm", "go_js_wasm_exec")
+	if _, err := os.Stat(execPath); err != nil {
+		execPath = filepath.Join(runtime.GOROOT(), "misc", "wasm", "go_js_wasm_exec")
+	}
+	if _, err := os.Stat(execPath); err != nil {
+		t.Skip("go_js_wasm_exec not found")
+	}
+
+	native, err := renderIsomorphic()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(filepath.Join(runtime.GOROOT(), "bin", "go"), "test", "-count=1", "-v", "-run", "^TestPrintRender$", "-exec", execPath, ".")
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm", printRenderEnv+"=1")
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running under js/wasm: %v\n%s", err, out)
+	}
+
+	begin := bytes.Index(out, renderBegin)
+	end := bytes.Index(out, renderEnd)
+	if begin < 0 || end < begin {
+		t.Fatalf("no render in js/wasm output:\n%s", out)
+	}
+
+	wasm, err := base64.StdEncoding.DecodeString(string(out[begin+len(renderBegin) : end]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(native, wasm) {
+		t.Errorf("native and js/wasm renders differ\nnative: %q\nwasm:   %q", native, wasm)
+	}
+}
@@ -0,0 +1,205 @@
+package islands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"sync"
+)
+
+// ErrDuplicateIsland is returned when registering
+// an Island whose name is already taken in a Registry
+var ErrDuplicateIsland = errors.New("an island with this name has already been registered")
+
+// ErrRegisteredElsewhere is returned when registering an
+// Island created with NewIsland that has already been
+// registered in another Registry, whose Islands its
+// template renders
+var ErrRegisteredElsewhere = errors.New("the island has already been registered in another registry")
+
+// Registry indexes Islands by their names so they
+// can be looked up from handlers and rendered from
+// other Islands' templates via
+// {{ island "name" }} or {{ island "name" payload }}
+type Registry struct {
+	mu      sync.RWMutex
+	islands map[string]Island
+}
+
+// DefaultRegistry is the process-wide Registry used
+// by Register and Lookup, and by any Island that
+// hasn't been registered in a Registry of its own
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		islands: make(map[string]Island),
+	}
+}
+
+// Register adds island to r under its name. It returns
+// ErrDuplicateIsland if the name is already taken, and
+// ErrRegisteredElsewhere if island was created with
+// NewIsland and is already registered in another Registry
+func (r *Registry) Register(island Island) error {
+	name := island.GetName()
+	if name == "" {
+		return errors.New("cannot register an island without a name")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.islands[name]; ok {
+		return fmt.Errorf("island %s: %w", name, ErrDuplicateIsland)
+	}
+
+	if n, ok := island.(*node); ok {
+		if n.registry != nil && n.registry != r {
+			return fmt.Errorf("island %s: %w", name, ErrRegisteredElsewhere)
+		}
+		n.registry = r
+	}
+
+	r.islands[name] = island
+
+	return nil
+}
+
+// MustRegister is like Register, except it accepts
+// multiple Islands at once and panics if any of
+// them fail to register
+func (r *Registry) MustRegister(islands ...Island) *Registry {
+	for i := range islands {
+		if err := r.Register(islands[i]); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Lookup returns the Island registered in r
+// with the given name, and whether it was found
+func (r *Registry) Lookup(name string) (Island, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	island, ok := r.islands[name]
+	return island, ok
+}
+
+// Names returns the names of all Islands
+// registered in r, sorted alphabetically
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.islands))
+	for name := range r.islands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Islands returns all Islands registered
+// in r, sorted by name
+func (r *Registry) Islands() []Island {
+	names := r.Names()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	islands := make([]Island, 0, len(names))
+	for _, name := range names {
+		if island, ok := r.islands[name]; ok {
+			islands = append(islands, island)
+		}
+	}
+
+	return islands
+}
+
+// FuncMap returns the template functions that
+// make r's Islands available inside of templates
+func (r *Registry) FuncMap() template.FuncMap {
+	return r.funcMap(nil)
+}
+
+// funcMap is like FuncMap, except the assets of
+// Islands rendered from a template are added to col
+func (r *Registry) funcMap(col *collector) template.FuncMap {
+	return template.FuncMap{
+		"island": func(name string, payload ...any) (template.HTML, error) {
+			return r.renderIsland(col, name, payload...)
+		},
+	}
+}
+
+// renderIsland renders the Island registered under name.
+// If a payload is given, the Island is rendered with it
+// as its {{ .payload }} without changing the registered
+// Island. Payloads are only supported for Islands
+// created with NewIsland, since other implementations
+// can't be rendered from a copy
+func (r *Registry) renderIsland(col *collector, name string, payload ...any) (template.HTML, error) {
+	island, ok := r.Lookup(name)
+	if !ok {
+		return "", fmt.Errorf("island %s is not registered", name)
+	}
+
+	col.include()
+
+	var p map[string]any
+	switch {
+	case len(payload) > 1:
+		return "", fmt.Errorf("island %s: expected at most one payload, got %d", name, len(payload))
+	case len(payload) == 1:
+		switch v := payload[0].(type) {
+		case map[string]any:
+			p = v
+		case nil:
+		default:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", fmt.Errorf("island %s: %w", name, err)
+			}
+			if err = json.Unmarshal(b, &p); err != nil {
+				return "", fmt.Errorf("island %s: %w", name, err)
+			}
+		}
+	}
+
+	var str string
+	var err error
+	switch n := island.(type) {
+	case *node:
+		if p != nil {
+			str, err = n.renderWithPayload(p, col)
+			break
+		}
+		str, err = n.renderCollect(col)
+	default:
+		if p != nil {
+			return "", fmt.Errorf("island %s: payloads can only be passed to islands created with NewIsland", name)
+		}
+		str, err = renderCollect(island, col)
+	}
+
+	return template.HTML(str), err
+}
+
+// Register adds island to the DefaultRegistry
+func Register(island Island) error {
+	return DefaultRegistry.Register(island)
+}
+
+// Lookup returns the Island registered in the
+// DefaultRegistry with the given name
+func Lookup(name string) (Island, bool) {
+	return DefaultRegistry.Lookup(name)
+}
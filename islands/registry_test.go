@@ -0,0 +1,70 @@
+package islands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// foreign is an Island that wasn't created with NewIsland
+type foreign struct {
+	Island
+}
+
+func TestRegisterInAnotherRegistry(t *testing.T) {
+	island := NewIsland("card", `<div>card</div>`)
+
+	err := NewRegistry().Register(island)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = NewRegistry().Register(island)
+	if !errors.Is(err, ErrRegisteredElsewhere) {
+		t.Errorf("expected ErrRegisteredElsewhere, got %v", err)
+	}
+}
+
+func TestRenderWithPayload(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(
+		NewIsland("greeting", `<p>hello {{ .payload.name }}</p>`),
+		NewIsland("page", `<div>{{ island "greeting" .payload }}</div>`),
+	)
+
+	page, _ := r.Lookup("page")
+	page.Hydrate(map[string]any{"name": "oasis"})
+
+	out, err := page.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "hello oasis") {
+		t.Errorf("expected the payload to be rendered, got %q", out)
+	}
+
+	greeting, _ := r.Lookup("greeting")
+	out, err = greeting.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, "oasis") {
+		t.Errorf("expected the registered island to be left untouched, got %q", out)
+	}
+}
+
+func TestRenderForeignIslandWithPayload(t *testing.T) {
+	r := NewRegistry()
+	r.MustRegister(
+		foreign{NewIsland("greeting", `<p>hello {{ .payload.name }}</p>`)},
+		NewIsland("page", `<div>{{ island "greeting" .payload }}</div>`),
+	)
+
+	page, _ := r.Lookup("page")
+	page.Hydrate(map[string]any{"name": "oasis"})
+
+	_, err := page.Render()
+	if err == nil || !strings.Contains(err.Error(), "NewIsland") {
+		t.Errorf("expected the payload to be rejected, got %v", err)
+	}
+}
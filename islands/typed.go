@@ -0,0 +1,128 @@
+package islands
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/syke99/oasis/internal"
+	"html/template"
+	"reflect"
+)
+
+// TypedIsland is an Island template whose props are
+// a Go type P instead of a map[string]any. P is the
+// root of the template's data, so its fields are
+// available in the template via {{ .Field }}
+type TypedIsland[P any] struct {
+	name     string
+	template string
+	tmpl     *template.Template
+}
+
+// NewTyped parses template and validates that
+// every field it references exists on P, so that
+// renaming or removing a field of P is caught
+// when the TypedIsland is created instead of when
+// a page is rendered. Other Islands are available
+// in template via {{ island "name" }}; they are
+// looked up when it is rendered, in the Registry
+// its Island is registered in, or else in the
+// DefaultRegistry
+func NewTyped[P any](name string, template string) (*TypedIsland[P], error) {
+	// only the names of the functions matter while
+	// parsing; they are bound to a Registry on render
+	tmpl, err := internal.Parse(template, NewRegistry().FuncMap())
+	if err != nil {
+		return nil, fmt.Errorf("island %s: %w", name, err)
+	}
+
+	err = internal.CheckFields(tmpl, reflect.TypeOf((*P)(nil)).Elem())
+	if err != nil {
+		return nil, fmt.Errorf("island %s: %w", name, err)
+	}
+
+	return &TypedIsland[P]{
+		name:     name,
+		template: template,
+		tmpl:     tmpl,
+	}, nil
+}
+
+// MustNewTyped is like NewTyped, except it
+// panics if template fails to parse or
+// references a field that doesn't exist on P.
+// It is meant to be used when declaring
+// TypedIslands as package level variables
+func MustNewTyped[P any](name string, template string) *TypedIsland[P] {
+	t, err := NewTyped[P](name, template)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// GetName returns the name of a TypedIsland
+func (t *TypedIsland[P]) GetName() string {
+	return t.name
+}
+
+// GetTemplate returns the template of a TypedIsland
+func (t *TypedIsland[P]) GetTemplate() string {
+	return t.template
+}
+
+// With returns an Island that renders t's template
+// with props, so it can be registered, added as a
+// child, cached, styled or made interactive like
+// any other Island. Since props is the root of the
+// template's data, the template can't reach the
+// Island's children, payload or map props
+func (t *TypedIsland[P]) With(props P) Island {
+	n := NewIsland(t.name, t.template).(*node)
+	n.typed = &typedProps{
+		tmpl:  t.tmpl,
+		props: props,
+	}
+	return n
+}
+
+// Render renders a TypedIsland's template with
+// props and returns the rendered template string
+// or an error if one occurs
+func (t *TypedIsland[P]) Render(props P) (string, error) {
+	return t.With(props).Render()
+}
+
+// typedProps are the props of an Island
+// created with TypedIsland's With
+type typedProps struct {
+	tmpl  *template.Template
+	props any
+}
+
+// render executes a copy of the parsed template,
+// which is never executed itself so that it can
+// keep being cloned, with funcs bound to it
+func (t *typedProps) render(funcs template.FuncMap) (string, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	return internal.Execute(tmpl.Funcs(funcs), t.props)
+}
+
+// propsMap converts props into the
+// map[string]any returned by GetProps
+func (t *typedProps) propsMap() map[string]any {
+	b, err := json.Marshal(t.props)
+	if err != nil {
+		return nil
+	}
+
+	m := make(map[string]any)
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+
+	return m
+}
@@ -0,0 +1,57 @@
+package islands
+
+import (
+	"strings"
+	"testing"
+)
+
+type cardProps struct {
+	Title string
+}
+
+func TestTypedIslandIsAnIsland(t *testing.T) {
+	card := MustNewTyped[cardProps]("card", `<div>{{ .Title }}</div>`)
+
+	var island Island = card.With(cardProps{Title: "oasis"}).Interactive()
+
+	out, err := island.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "oasis") {
+		t.Errorf("expected the props to be rendered, got %q", out)
+	}
+	if !strings.Contains(out, `&#34;Title&#34;:&#34;oasis&#34;`) {
+		t.Errorf("expected the props to be serialized, got %q", out)
+	}
+	if got := island.GetProps()["Title"]; got != "oasis" {
+		t.Errorf("expected GetProps to hold the props, got %v", got)
+	}
+}
+
+func TestTypedIslandLooksUpItsRegistryOnRender(t *testing.T) {
+	card := MustNewTyped[cardProps]("card", `<div>{{ .Title }}{{ island "badge" }}</div>`)
+
+	r := NewRegistry()
+	r.MustRegister(
+		NewIsland("badge", `<span>badge</span>`),
+		card.With(cardProps{Title: "oasis"}),
+	)
+
+	island, _ := r.Lookup("card")
+
+	out, err := island.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "badge") {
+		t.Errorf("expected the badge to be rendered from the registry, got %q", out)
+	}
+}
+
+func TestNewTypedMissingField(t *testing.T) {
+	_, err := NewTyped[cardProps]("card", `{{ define "title" }}{{ .Subtitle }}{{ end }}<div>{{ template "title" . }}</div>`)
+	if err == nil || !strings.Contains(err.Error(), "Subtitle") {
+		t.Errorf("expected Subtitle to be reported missing, got %v", err)
+	}
+}
@@ -18,11 +18,20 @@ type Endpoint struct {
 // HandlerWithMiddleware ties a http.HandlerFunc
 // and islands.Island together, along with any
 // middleware you want this handler to be passed
-// through
+// through. If CacheControl is set, it is sent
+// as the Cache-Control header of every response
+// rendered from Island. OOB Islands are rendered
+// after Island and swapped in out-of-band by htmx.
+// If Layout is set, requests that weren't made by
+// htmx get a full page with Island rendered into
+// Layout
 type HandlerWithMiddleware struct {
-	HandlerFunc http.HandlerFunc
-	Middleware  []http.Handler
-	Island      islands.Island
+	HandlerFunc  http.HandlerFunc
+	Middleware   []http.Handler
+	Island       islands.Island
+	CacheControl string
+	OOB          []islands.Island
+	Layout       *islands.Layout
 }
 
 type HTTPMethod string
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxValidators caps how many distinct renderings of
+// a handler's Island have their first-seen time kept
+const maxValidators = 256
+
+// validators remembers when each distinct rendering
+// of a handler's Island was first served so it can
+// be sent as that rendering's Last-Modified time
+type validators struct {
+	mu       sync.Mutex
+	modified map[string]time.Time
+}
+
+func newValidators() *validators {
+	return &validators{
+		modified: make(map[string]time.Time),
+	}
+}
+
+func (v *validators) lastModified(etag string) time.Time {
+	if v == nil {
+		return time.Now().UTC().Truncate(time.Second)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if t, ok := v.modified[etag]; ok {
+		return t
+	}
+
+	if len(v.modified) >= maxValidators {
+		v.modified = make(map[string]time.Time)
+	}
+
+	t := time.Now().UTC().Truncate(time.Second)
+	v.modified[etag] = t
+
+	return t
+}
+
+func strongETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified reports whether r's conditional headers
+// match etag or modified. If-None-Match takes precedence
+// over If-Modified-Since, as required by RFC 9110
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" {
+		return false
+	}
+
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(t)
+}
+
+func addVary(h http.Header, header string) {
+	for _, v := range h.Values("Vary") {
+		for _, field := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), header) {
+				return
+			}
+		}
+	}
+
+	h.Add("Vary", header)
+}
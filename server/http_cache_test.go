@@ -0,0 +1,134 @@
+package server
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/syke99/oasis/islands"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func cachedRouter() *mux.Router {
+	m := mux.NewRouter()
+
+	UpgradeRouter(m).AddEndpoint(NewEndpoint("/greeting", map[HTTPMethod]HandlerWithMiddleware{
+		MethodGet: {
+			Island:       islands.NewIsland("greeting", `<p>hello {{ .payload.name }}</p>`),
+			CacheControl: "max-age=60",
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"name":"` + r.URL.Query().Get("name") + `"}`))
+			},
+		},
+	}))
+
+	return m
+}
+
+func get(m http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestConditionalRequests(t *testing.T) {
+	m := cachedRouter()
+
+	first := get(m, "/greeting?name=oasis", nil)
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", first.Code)
+	}
+
+	etag := first.Header().Get("ETag")
+	modified := first.Header().Get("Last-Modified")
+	if etag == "" || modified == "" {
+		t.Fatalf("expected validators, got ETag %q and Last-Modified %q", etag, modified)
+	}
+	if cc := first.Header().Get("Cache-Control"); cc != "max-age=60" {
+		t.Errorf("expected Cache-Control max-age=60, got %q", cc)
+	}
+
+	rec := get(m, "/greeting?name=oasis", http.Header{"If-None-Match": {etag}})
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("If-None-Match: expected an empty 304, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = get(m, "/greeting?name=oasis", http.Header{"If-Modified-Since": {modified}})
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since: expected 304, got %d", rec.Code)
+	}
+
+	rec = get(m, "/greeting?name=desert", http.Header{"If-None-Match": {etag}})
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("changed body: expected 200 with a new ETag, got %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+}
+
+func TestNotModifiedPrecedence(t *testing.T) {
+	modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+	req.Header.Set("If-Modified-Since", modified.Format(http.TimeFormat))
+
+	if notModified(req, `"etag"`, modified) {
+		t.Error("expected a mismatched If-None-Match to override If-Modified-Since")
+	}
+
+	req.Header.Set("If-None-Match", `W/"etag"`)
+	if !notModified(req, `"etag"`, modified) {
+		t.Error("expected a weak match to count as not modified")
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("If-None-Match", "*")
+	if notModified(req, `"etag"`, modified) {
+		t.Error("expected unsafe methods never to be not modified")
+	}
+}
+
+func TestPartialAndPageValidators(t *testing.T) {
+	m := mux.NewRouter()
+
+	UpgradeRouter(m).AddEndpoint(NewEndpoint("/greeting", map[HTTPMethod]HandlerWithMiddleware{
+		MethodGet: {
+			Island: islands.NewIsland("greeting", `<p>hello {{ .payload.name }}</p>`),
+			Layout: islands.NewLayout(`<html><body>{{ .content }}</body></html>`),
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("vary") != "" {
+					w.Header().Set("Vary", "Accept-Encoding, HX-Request")
+				}
+				w.Write([]byte(`{"name":"oasis"}`))
+			},
+		},
+	}))
+
+	page := get(m, "/greeting", nil)
+	partial := get(m, "/greeting", http.Header{"Hx-Request": {"true"}})
+
+	if page.Header().Get("ETag") == partial.Header().Get("ETag") {
+		t.Errorf("expected the page and the partial to have different ETags, got %q", page.Header().Get("ETag"))
+	}
+
+	for name, rec := range map[string]*httptest.ResponseRecorder{"page": page, "partial": partial} {
+		if vary := rec.Header().Values("Vary"); len(vary) != 1 || vary[0] != "HX-Request" {
+			t.Errorf("%s: expected Vary HX-Request, got %q", name, vary)
+		}
+	}
+
+	rec := get(m, "/greeting", http.Header{"Hx-Request": {"true"}, "If-None-Match": {page.Header().Get("ETag")}})
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected the page's ETag not to match the partial, got %d", rec.Code)
+	}
+
+	rec = get(m, "/greeting?vary=1", nil)
+	if vary := rec.Header().Values("Vary"); len(vary) != 1 || vary[0] != "Accept-Encoding, HX-Request" {
+		t.Errorf("expected the existing Vary not to be duplicated, got %q", vary)
+	}
+}
@@ -4,61 +4,46 @@ import (
 	"context"
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/mux"
+	"github.com/syke99/oasis/islands"
 	"net/http"
 )
 
-func mountRoutesChi(mx *chi.Mux, endpoint Endpoint) *chi.Mux {
-	// create a sub-router for this endpoint
-	rtr := chi.NewRouter()
-
-	rtr.Route(endpoint.Route, func(r chi.Router) {
-		for method, handler := range endpoint.Handlers {
-			// create method-specific sub-router
-			sub := chi.NewRouter()
-
-			// if the handler uses middleware, add said
-			// middleware to handler
-			if handler.Middleware != nil || len(handler.Middleware) != 0 {
-				mw := make([]func(http.Handler) http.Handler, len(handler.Middleware))
-				for i := range handler.Middleware {
-					mw[i] = func(h http.Handler) http.Handler {
-						return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-							handler.Middleware[i].ServeHTTP(w, r)
-						})
-					}
-				}
-
-				sub.Use(mw...)
-			}
-
-			// add the handler to the specified method
-			sub.Method(string(method), "", func() http.Handler {
-				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-					oW := NewOasisWriter(w, handler.Island)
-
-					r.WithContext(context.WithValue(r.Context(), "props", handler.Island.GetProps()))
+func mountRoutesChi(mx *chi.Mux, endpoint Endpoint, registry *islands.Registry) *chi.Mux {
+	for method, handler := range endpoint.Handlers {
+		h := serveHandler(handler, registry)
+		mw := handler.Middleware
 
-					handler.HandlerFunc(oW, r)
-				})
-			}())
-
-			// mount the method-specific sub-router
-			// to the main sub-router
-			rtr.Mount("", sub)
-		}
-	})
+		// register each method on its own, so chi
+		// answers the methods an Endpoint has no
+		// handler for with 405
+		mx.Method(string(method), endpoint.Route, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// serve through middleware
+			for i := range mw {
+				mw[i].ServeHTTP(w, r)
+			}
 
-	// mount the sub-router at the specified route
-	mx.Mount(endpoint.Route, rtr)
+			// hit last HandlerFunc
+			h(w, r)
+		}))
+	}
 
 	return mx
 }
 
-func mountRoutesGorilla(mx *mux.Router, endpoint Endpoint) *mux.Router {
+func mountRoutesGorilla(mx *mux.Router, endpoint Endpoint, registry *islands.Registry) *mux.Router {
+	served := make(map[HTTPMethod]http.HandlerFunc, len(endpoint.Handlers))
+	for method, handler := range endpoint.Handlers {
+		served[method] = serveHandler(handler, registry)
+	}
+
 	handlersByMethod := func(w http.ResponseWriter, r *http.Request) {
-		handlerWithMiddleware := endpoint.Handlers[HTTPMethod(r.Method)]
+		handlerWithMiddleware, ok := endpoint.Handlers[HTTPMethod(r.Method)]
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
 
-		h := handlerWithMiddleware.HandlerFunc
+		h := served[HTTPMethod(r.Method)]
 
 		mw := handlerWithMiddleware.Middleware
 
@@ -85,3 +70,27 @@ func mountRoutesGorilla(mx *mux.Router, endpoint Endpoint) *mux.Router {
 func handlerFuncToHandler(handler http.HandlerFunc) http.Handler {
 	return handler
 }
+
+// serveHandler wraps a HandlerWithMiddleware's HandlerFunc
+// so that it writes through its Island and can access
+// its props and the Router's Registry from the request
+func serveHandler(handler HandlerWithMiddleware, registry *islands.Registry) http.HandlerFunc {
+	v := newValidators()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), registryKey, registry)
+
+		if handler.Island == nil {
+			handler.HandlerFunc(w, r.WithContext(ctx))
+			return
+		}
+
+		ctx = context.WithValue(ctx, propsKey, handler.Island.GetProps())
+
+		oW := newOasisWriter(w, r, handler, registry, v)
+
+		handler.HandlerFunc(oW, r.WithContext(ctx))
+
+		oW.flush()
+	}
+}
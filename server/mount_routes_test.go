@@ -0,0 +1,68 @@
+package server
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGorillaMethodNotAllowed(t *testing.T) {
+	m := mux.NewRouter()
+
+	UpgradeRouter(m).AddEndpoint(NewEndpoint("/ping", map[HTTPMethod]HandlerWithMiddleware{
+		MethodGet: {
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("pong"))
+			},
+		},
+	}))
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "pong" {
+		t.Errorf("GET: expected 200 pong, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ping", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: expected 405, got %d", rec.Code)
+	}
+}
+
+func TestChiEndpoint(t *testing.T) {
+	m := chi.NewRouter()
+
+	UpgradeRouter(m).AddEndpoint(NewEndpoint("/ping", map[HTTPMethod]HandlerWithMiddleware{
+		MethodGet: {
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("pong"))
+			},
+		},
+		MethodPut: {
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("put"))
+			},
+		},
+	}))
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "pong" {
+		t.Errorf("GET: expected 200 pong, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/ping", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "put" {
+		t.Errorf("PUT: expected 200 put, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ping", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: expected 405, got %d", rec.Code)
+	}
+}
@@ -12,9 +12,17 @@ import (
 // Router wraps a chi.Router
 // to add Endpoints to
 type Router struct {
-	mux any
+	mux      any
+	registry *islands.Registry
 }
 
+type contextKey string
+
+const (
+	propsKey    = contextKey("props")
+	registryKey = contextKey("registry")
+)
+
 type Valid interface {
 	*mux.Router | *chi.Mux
 }
@@ -42,13 +50,32 @@ func UpgradeRouter[V Valid](router V) *Router {
 func (r *Router) AddEndpoint(endpoint Endpoint) *Router {
 	switch r.mux.(type) {
 	case *mux.Router:
-		r.mux = mountRoutesGorilla(r.mux.(*mux.Router), endpoint)
+		r.mux = mountRoutesGorilla(r.mux.(*mux.Router), endpoint, r.Registry())
 	case *chi.Mux:
-		r.mux = mountRoutesChi(r.mux.(*chi.Mux), endpoint)
+		r.mux = mountRoutesChi(r.mux.(*chi.Mux), endpoint, r.Registry())
 	}
 	return r
 }
 
+// WithRegistry scopes a Router to the given
+// *islands.Registry so that its handlers look
+// up Islands in registry instead of in
+// islands.DefaultRegistry. It must be called
+// before any Endpoints are added
+func (r *Router) WithRegistry(registry *islands.Registry) *Router {
+	r.registry = registry
+	return r
+}
+
+// Registry returns the *islands.Registry a
+// Router's handlers look up Islands in
+func (r *Router) Registry() *islands.Registry {
+	if r.registry != nil {
+		return r.registry
+	}
+	return islands.DefaultRegistry
+}
+
 // AddEndpoints is like AddEndpoint, but adds
 // multiple endpoints at once
 func (r *Router) AddEndpoints(endpoints ...Endpoint) *Router {
@@ -62,7 +89,7 @@ func (r *Router) AddEndpoints(endpoints ...Endpoint) *Router {
 // from an Endpoint's Island by passing in the
 // request
 func PropsForRequest(r *http.Request) map[string]any {
-	val := r.Context().Value("props")
+	val := r.Context().Value(propsKey)
 	if val != nil {
 		return val.(map[string]any)
 	}
@@ -70,6 +97,17 @@ func PropsForRequest(r *http.Request) map[string]any {
 	return nil
 }
 
+// LookupIsland returns the Island registered under
+// name in the Registry of the Router serving the
+// request, falling back to islands.DefaultRegistry
+func LookupIsland(r *http.Request, name string) (islands.Island, bool) {
+	if registry, ok := r.Context().Value(registryKey).(*islands.Registry); ok {
+		return registry.Lookup(name)
+	}
+
+	return islands.Lookup(name)
+}
+
 func (r *Router) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
 	switch r.mux.(type) {
 	case *mux.Router:
@@ -98,16 +136,44 @@ func NewOasisWriter(w http.ResponseWriter, island islands.Island) OasisWriter {
 }
 
 type oasisWriter struct {
-	island islands.Island
-	writer http.ResponseWriter
+	island       islands.Island
+	oob          []islands.Island
+	layout       *islands.Layout
+	registry     *islands.Registry
+	writer       http.ResponseWriter
+	request      *http.Request
+	cacheControl string
+	validators   *validators
+	status       int
+	wroteHeader  bool
+}
+
+func newOasisWriter(w http.ResponseWriter, r *http.Request, handler HandlerWithMiddleware, registry *islands.Registry, v *validators) *oasisWriter {
+	return &oasisWriter{
+		island:       handler.Island,
+		registry:     registry,
+		oob:          handler.OOB,
+		layout:       handler.Layout,
+		writer:       w,
+		request:      r,
+		cacheControl: handler.CacheControl,
+		validators:   v,
+	}
 }
 
 func (o *oasisWriter) Header() http.Header {
 	return o.writer.Header()
 }
 
+// WriteHeader holds on to statusCode until the
+// Island has been rendered, so that a 304 can be
+// sent instead if the client's copy is still fresh
 func (o *oasisWriter) WriteHeader(statusCode int) {
-	o.writer.WriteHeader(statusCode)
+	if o.wroteHeader || o.request == nil {
+		o.writer.WriteHeader(statusCode)
+		return
+	}
+	o.status = statusCode
 }
 
 func (o *oasisWriter) Write(p []byte) (n int, err error) {
@@ -120,14 +186,86 @@ func (o *oasisWriter) Write(p []byte) (n int, err error) {
 
 	payload := NewPayload()
 
-	err = json.Unmarshal(p, payload.payload)
+	err = json.Unmarshal(p, &payload.payload)
 	if err != nil {
 		return 0, err
 	}
 
 	o.island.Hydrate(payload.payload)
 
-	return o.writer.Write([]byte(islands.MustRender(o.island)))
+	// htmx requests only need the fragment, so
+	// the layout is only used for full pages
+	layout := o.layout
+	if o.request != nil && o.request.Header.Get("HX-Request") == "true" {
+		layout = nil
+	}
+
+	registry := o.registry
+	if registry == nil {
+		registry = islands.DefaultRegistry
+	}
+
+	page, err := registry.RenderPage(layout, o.island, o.oob...)
+	if err != nil {
+		return 0, err
+	}
+
+	body := []byte(page)
+
+	if o.wroteHeader || o.request == nil {
+		return o.writer.Write(body)
+	}
+
+	o.wroteHeader = true
+
+	if o.writeNotModified(body) {
+		return len(p), nil
+	}
+
+	if o.status != 0 {
+		o.writer.WriteHeader(o.status)
+	}
+
+	return o.writer.Write(body)
+}
+
+// writeNotModified sets the caching headers for body and
+// responds with a 304 if the request's conditional headers
+// show the client already has it. It reports whether the
+// 304 was sent
+func (o *oasisWriter) writeNotModified(body []byte) bool {
+	h := o.writer.Header()
+
+	etag := strongETag(body)
+	modified := o.validators.lastModified(etag)
+
+	h.Set("ETag", etag)
+	h.Set("Last-Modified", modified.Format(http.TimeFormat))
+	addVary(h, "HX-Request")
+
+	if o.cacheControl != "" {
+		h.Set("Cache-Control", o.cacheControl)
+	}
+
+	if (o.status != 0 && o.status != http.StatusOK) || !notModified(o.request, etag, modified) {
+		return false
+	}
+
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	o.writer.WriteHeader(http.StatusNotModified)
+
+	return true
+}
+
+// flush sends a status code that was set
+// with WriteHeader but never followed by
+// a call to Write
+func (o *oasisWriter) flush() {
+	if !o.wroteHeader && o.status != 0 {
+		o.wroteHeader = true
+		o.writer.WriteHeader(o.status)
+	}
 }
 
 // OasisPayload is a convenience
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/syke99/oasis/islands"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOasisWriterRendersPayload(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	w := NewOasisWriter(rec, islands.NewIsland("greeting", `<p>{{ .payload.name }}</p>`))
+
+	payload := NewPayload()
+	payload.Set("name", "oasis")
+
+	b, err := payload.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = w.Write(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(rec.Body.String(), "oasis") {
+		t.Errorf("expected the payload to be rendered, got %q", rec.Body.String())
+	}
+}
+
+func TestLayoutUsesRouterRegistry(t *testing.T) {
+	registry := islands.NewRegistry()
+	registry.MustRegister(islands.NewIsland("nav", `<nav>scoped nav</nav>`))
+
+	router := UpgradeRouter(mux.NewRouter()).WithRegistry(registry)
+	router.AddEndpoint(NewEndpoint("/", map[HTTPMethod]HandlerWithMiddleware{
+		MethodGet: {
+			HandlerFunc: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{}`))
+			},
+			Island: islands.NewIsland("main", `<main>main</main>`),
+			Layout: islands.NewLayout(`<html><body>{{ island "nav" }}{{ .content }}</body></html>`),
+		},
+	}))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if !strings.Contains(rec.Body.String(), "scoped nav") {
+		t.Errorf("expected the layout to render the Router's Islands, got %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/syke99/oasis/internal"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// RPCEndpoints exposes every method of the interface I,
+// as implemented by impl, as an Endpoint accepting POST
+// requests at prefix/(method name), so that it can be
+// called from the client with typed arguments and results
+// through a stub bound with the client/rpc package. Every
+// method of I may take a context.Context as its first
+// argument, which will be the request's context, and must
+// return either an error, or a result and an error
+func RPCEndpoints[I any](prefix string, impl I) ([]Endpoint, error) {
+	methods, err := internal.RPCMethods(reflect.TypeOf((*I)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	recv := reflect.ValueOf(impl)
+	if !recv.IsValid() {
+		return nil, fmt.Errorf("rpc: cannot expose a nil implementation")
+	}
+
+	endpoints := make([]Endpoint, len(methods))
+
+	for i, method := range methods {
+		endpoints[i] = NewEndpoint(strings.TrimSuffix(prefix, "/")+"/"+method.Name, map[HTTPMethod]HandlerWithMiddleware{
+			MethodPost: {
+				HandlerFunc: rpcHandler(recv.MethodByName(method.Name), method),
+			},
+		})
+	}
+
+	return endpoints, nil
+}
+
+func rpcHandler(fn reflect.Value, method internal.RPCMethod) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req := internal.RPCRequest{}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			writeRPC(w, http.StatusBadRequest, internal.RPCResponse{
+				Error: fmt.Sprintf("rpc: invalid request to %s: %s", method.Name, err.Error()),
+			})
+			return
+		}
+
+		if len(req.Args) != len(method.Args) {
+			writeRPC(w, http.StatusBadRequest, internal.RPCResponse{
+				Error: fmt.Sprintf("rpc: %s takes %d arguments, got %d", method.Name, len(method.Args), len(req.Args)),
+			})
+			return
+		}
+
+		args := make([]reflect.Value, 0, len(method.Args)+1)
+
+		if method.HasContext {
+			args = append(args, reflect.ValueOf(r.Context()))
+		}
+
+		for i, typ := range method.Args {
+			arg := reflect.New(typ)
+
+			err = json.Unmarshal(req.Args[i], arg.Interface())
+			if err != nil {
+				writeRPC(w, http.StatusBadRequest, internal.RPCResponse{
+					Error: fmt.Sprintf("rpc: invalid argument %d to %s: %s", i, method.Name, err.Error()),
+				})
+				return
+			}
+
+			args = append(args, arg.Elem())
+		}
+
+		out := fn.Call(args)
+
+		if err, _ := out[len(out)-1].Interface().(error); err != nil {
+			writeRPC(w, http.StatusInternalServerError, internal.RPCResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		res := internal.RPCResponse{}
+
+		if method.Result != nil {
+			res.Result, err = json.Marshal(out[0].Interface())
+			if err != nil {
+				writeRPC(w, http.StatusInternalServerError, internal.RPCResponse{
+					Error: fmt.Sprintf("rpc: could not encode result of %s: %s", method.Name, err.Error()),
+				})
+				return
+			}
+		}
+
+		writeRPC(w, http.StatusOK, res)
+	}
+}
+
+func writeRPC(w http.ResponseWriter, status int, res internal.RPCResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
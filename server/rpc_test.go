@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"github.com/syke99/oasis/internal"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type rpcCtxKey struct{}
+
+type rpcItem struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type rpcService interface {
+	Greet(ctx context.Context, name string) (string, error)
+	Add(a int, b int) (int, error)
+	Items() ([]rpcItem, error)
+	Fail() (string, error)
+	Reset() error
+	Clear() error
+}
+
+type rpcImpl struct {
+	reset bool
+}
+
+func (s *rpcImpl) Greet(ctx context.Context, name string) (string, error) {
+	return ctx.Value(rpcCtxKey{}).(string) + " " + name, nil
+}
+
+func (s *rpcImpl) Add(a int, b int) (int, error) {
+	return a + b, nil
+}
+
+func (s *rpcImpl) Items() ([]rpcItem, error) {
+	return []rpcItem{{Name: "oasis", Count: 2}}, nil
+}
+
+func (s *rpcImpl) Fail() (string, error) {
+	return "", errors.New("the well is dry")
+}
+
+func (s *rpcImpl) Reset() error {
+	s.reset = true
+	return nil
+}
+
+func (s *rpcImpl) Clear() error {
+	return errors.New("nothing to clear")
+}
+
+func TestRPCEndpoints(t *testing.T) {
+	impl := &rpcImpl{}
+
+	endpoints, err := RPCEndpoints[rpcService]("/rpc/", impl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := mux.NewRouter()
+	UpgradeRouter(m).AddEndpoints(endpoints...)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+		result string
+		err    string
+	}{
+		{
+			name:   "context",
+			method: "Greet",
+			body:   `{"args":["oasis"]}`,
+			status: http.StatusOK,
+			result: `"hello oasis"`,
+		},
+		{
+			name:   "arguments",
+			method: "Add",
+			body:   `{"args":[1,2]}`,
+			status: http.StatusOK,
+			result: `3`,
+		},
+		{
+			name:   "encoded result",
+			method: "Items",
+			body:   `{"args":[]}`,
+			status: http.StatusOK,
+			result: `[{"name":"oasis","count":2}]`,
+		},
+		{
+			name:   "too few arguments",
+			method: "Add",
+			body:   `{"args":[1]}`,
+			status: http.StatusBadRequest,
+			err:    "Add takes 2 arguments, got 1",
+		},
+		{
+			name:   "too many arguments",
+			method: "Greet",
+			body:   `{"args":["oasis","desert"]}`,
+			status: http.StatusBadRequest,
+			err:    "Greet takes 1 arguments, got 2",
+		},
+		{
+			name:   "malformed request",
+			method: "Add",
+			body:   `{"args":[1,`,
+			status: http.StatusBadRequest,
+			err:    "invalid request to Add",
+		},
+		{
+			name:   "malformed argument",
+			method: "Add",
+			body:   `{"args":[1,"two"]}`,
+			status: http.StatusBadRequest,
+			err:    "invalid argument 1 to Add",
+		},
+		{
+			name:   "error",
+			method: "Fail",
+			body:   `{"args":[]}`,
+			status: http.StatusInternalServerError,
+			err:    "the well is dry",
+		},
+		{
+			name:   "error only",
+			method: "Reset",
+			body:   `{"args":[]}`,
+			status: http.StatusOK,
+		},
+		{
+			name:   "error only failing",
+			method: "Clear",
+			body:   `{"args":[]}`,
+			status: http.StatusInternalServerError,
+			err:    "nothing to clear",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/rpc/"+tt.method, strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), rpcCtxKey{}, "hello"))
+
+			rec := httptest.NewRecorder()
+			m.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d %q", tt.status, rec.Code, rec.Body.String())
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected application/json, got %q", ct)
+			}
+
+			res := internal.RPCResponse{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+
+			if string(res.Result) != tt.result {
+				t.Errorf("expected result %s, got %s", tt.result, res.Result)
+			}
+			if (tt.err == "" && res.Error != "") || !strings.Contains(res.Error, tt.err) {
+				t.Errorf("expected error %q, got %q", tt.err, res.Error)
+			}
+		})
+	}
+
+	if !impl.reset {
+		t.Error("expected Reset to be called")
+	}
+}
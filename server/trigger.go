@@ -0,0 +1,58 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// TriggerHeader is the response header htmx reads
+// to trigger events on the client once a response
+// has been received
+const TriggerHeader = "HX-Trigger"
+
+// TriggerEvent makes htmx trigger the event name, with
+// detail encoded as JSON as its detail, on the element
+// that made the request once w's response is received.
+// The event bubbles up to the document, where it can be
+// received in Go types with the client/dom package's
+// Subscribe. Events triggered earlier in the same
+// response are kept. detail should encode to a JSON
+// object, since htmx wraps any other value in one as
+// its "value". TriggerEvent must be called before the
+// response's headers are written
+func TriggerEvent(w http.ResponseWriter, name string, detail any) error {
+	b, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+
+	triggers := make(map[string]json.RawMessage)
+
+	if existing := strings.TrimSpace(w.Header().Get(TriggerHeader)); existing != "" {
+		if strings.HasPrefix(existing, "{") {
+			err = json.Unmarshal([]byte(existing), &triggers)
+			if err != nil {
+				return err
+			}
+		} else {
+			// a plain, comma-separated list of event names
+			for _, n := range strings.Split(existing, ",") {
+				if n = strings.TrimSpace(n); n != "" {
+					triggers[n] = json.RawMessage("null")
+				}
+			}
+		}
+	}
+
+	triggers[name] = b
+
+	b, err = json.Marshal(triggers)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set(TriggerHeader, string(b))
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestTriggerEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     map[string]any
+	}{
+		{
+			name: "no header",
+			want: map[string]any{
+				"saved": map[string]any{"id": float64(1)},
+			},
+		},
+		{
+			name:     "json",
+			existing: `{"loaded":{"page":2},"refresh":null}`,
+			want: map[string]any{
+				"loaded":  map[string]any{"page": float64(2)},
+				"refresh": nil,
+				"saved":   map[string]any{"id": float64(1)},
+			},
+		},
+		{
+			name:     "list",
+			existing: "loaded, refresh",
+			want: map[string]any{
+				"loaded":  nil,
+				"refresh": nil,
+				"saved":   map[string]any{"id": float64(1)},
+			},
+		},
+		{
+			name:     "replaced",
+			existing: `{"saved":{"id":0}}`,
+			want: map[string]any{
+				"saved": map[string]any{"id": float64(1)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if tt.existing != "" {
+				rec.Header().Set(TriggerHeader, tt.existing)
+			}
+
+			err := TriggerEvent(rec, "saved", map[string]int{"id": 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string]any)
+			if err = json.Unmarshal([]byte(rec.Header().Get(TriggerHeader)), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTriggerEventInvalidHeader(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(TriggerHeader, `{"loaded":`)
+
+	if err := TriggerEvent(rec, "saved", nil); err == nil {
+		t.Error("expected an invalid HX-Trigger header to be reported")
+	}
+}